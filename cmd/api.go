@@ -4,17 +4,35 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
 	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/internal/block"
+	"github.com/etherealsense/social-network/internal/bookmark"
 	"github.com/etherealsense/social-network/internal/chat"
 	"github.com/etherealsense/social-network/internal/comment"
 	"github.com/etherealsense/social-network/internal/feed"
 	"github.com/etherealsense/social-network/internal/follow"
+	"github.com/etherealsense/social-network/internal/hashtag"
+	"github.com/etherealsense/social-network/internal/impression"
 	"github.com/etherealsense/social-network/internal/like"
+	"github.com/etherealsense/social-network/internal/media"
+	"github.com/etherealsense/social-network/internal/mention"
+	"github.com/etherealsense/social-network/internal/mute"
+	"github.com/etherealsense/social-network/internal/notification"
+	"github.com/etherealsense/social-network/internal/poll"
 	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/internal/preview"
+	"github.com/etherealsense/social-network/internal/reaction"
+	"github.com/etherealsense/social-network/internal/repost"
+	"github.com/etherealsense/social-network/internal/search"
+	"github.com/etherealsense/social-network/internal/trash"
 	"github.com/etherealsense/social-network/internal/user"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/markdown"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -23,23 +41,43 @@ import (
 )
 
 type application struct {
-	config config
-	db     *pgxpool.Pool
-	server *http.Server
+	config  config
+	db      *pgxpool.Pool
+	storage media.Storage
+	server  *http.Server
+	workers []worker
+	wg      sync.WaitGroup
+	stop    context.CancelFunc
+}
+
+// worker is a background job started alongside the http server and stopped
+// on shutdown.
+type worker interface {
+	Run(ctx context.Context)
 }
 
 type config struct {
-	env  string
-	addr string
-	db   dbConfig
-	cors corsConfig
-	auth auth.Config
+	env       string
+	addr      string
+	db        dbConfig
+	cors      corsConfig
+	auth      auth.Config
+	media     mediaConfig
+	reactions []string
+	// cursorSecret signs pagination cursors, so clients cannot forge sort
+	// keys.
+	cursorSecret string
 }
 
 type dbConfig struct {
 	dsn string
 }
 
+type mediaConfig struct {
+	dir     string
+	baseURL string
+}
+
 type corsConfig struct {
 	origins []string
 }
@@ -66,8 +104,13 @@ func (app *application) mount() http.Handler {
 		w.Write([]byte("ok"))
 	})
 
+	r.Handle("/media/*", http.StripPrefix("/media/", noDirListing(http.FileServer(http.Dir(app.config.media.dir)))))
+
 	r.Route("/api/v1", func(r chi.Router) {
-		repository := repo.New(app.db)
+		r.Use(markdown.Negotiate)
+
+		db := database.NewDB(app.db)
+		repository := repo.New(db)
 
 		authService := auth.NewService(repository)
 		authHandler := auth.NewHandler(authService, app.config.auth)
@@ -81,6 +124,16 @@ func (app *application) mount() http.Handler {
 			r.Get("/chats/{chat_id}/ws", chatHandler.HandleWebSocket)
 		})
 
+		mediaService := media.NewService(repository, app.storage)
+		mediaHandler := media.NewHandler(mediaService)
+		app.workers = append(app.workers, media.NewCollector(repository, app.storage, time.Hour))
+
+		// Uploads enforce their own, larger body limit.
+		r.Group(func(r chi.Router) {
+			auth.RequireAuth(authHandler)(r)
+			r.Post("/attachments", mediaHandler.Upload)
+		})
+
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.Timeout(time.Minute))
 			r.Use(func(next http.Handler) http.Handler {
@@ -111,28 +164,107 @@ func (app *application) mount() http.Handler {
 				r.Put("/users/me", userHandler.UpdateUser)
 			})
 
-			postService := post.NewService(repository)
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Put("/attachments/{id}", mediaHandler.UpdateAttachment)
+			})
+
+			pollService := poll.NewService(repository)
+			pollHandler := poll.NewHandler(pollService)
+			app.workers = append(app.workers, poll.NewCloser(repository, 30*time.Second))
+
+			previewService := preview.NewService(repository)
+			app.workers = append(app.workers, preview.NewWorker(repository, preview.NewFetcher(), 5*time.Second))
+
+			impressions := impression.NewBuffer(repository, 10*time.Second)
+			app.workers = append(app.workers, impressions)
+
+			reactionService := reaction.NewService(repository, app.config.reactions)
+			reactionHandler := reaction.NewHandler(reactionService)
+
+			postService := post.NewService(repository, db, mediaService, pollService, previewService, reactionService, impressions)
 			postHandler := post.NewHandler(postService)
-			r.Get("/posts/{id}", postHandler.GetPost)
-			r.Get("/posts/user/{user_id}", postHandler.ListPostsByUserID)
+			app.workers = append(app.workers, post.NewScheduler(repository, 30*time.Second))
+
+			r.Group(func(r chi.Router) {
+				auth.OptionalAuth(authHandler)(r)
+				r.Get("/posts/{id}", postHandler.GetPost)
+				r.Get("/posts/user/{user_id}", postHandler.ListPostsByUserID)
+				r.Get("/posts/{id}/revisions", postHandler.ListRevisions)
+				r.Get("/posts/{id}/revisions/diff", postHandler.DiffRevisions)
+			})
 
 			r.Group(func(r chi.Router) {
 				auth.RequireAuth(authHandler)(r)
+				r.Get("/posts/drafts", postHandler.ListDrafts)
 				r.Post("/posts", postHandler.CreatePost)
 				r.Put("/posts/{id}", postHandler.UpdatePost)
 				r.Delete("/posts/{id}", postHandler.DeletePost)
+				r.Post("/posts/{id}/restore", postHandler.RestorePost)
+				r.Post("/posts/{id}/revisions/{revision_id}/restore", postHandler.RestoreRevision)
+				r.Post("/posts/{post_id}/poll/votes", pollHandler.Vote)
+				r.Post("/posts/{id}/pin", postHandler.PinPost)
+				r.Delete("/posts/{id}/pin", postHandler.UnpinPost)
+				r.Put("/posts/{id}/comment-settings", postHandler.UpdateCommentSettings)
+				r.Put("/users/me/pins", postHandler.ReorderPins)
 			})
 
-			commentService := comment.NewService(repository)
+			hashtagService := hashtag.NewService(repository, postService)
+			hashtagHandler := hashtag.NewHandler(hashtagService)
+			r.Get("/hashtags/trending", hashtagHandler.ListTrending)
+
+			r.Group(func(r chi.Router) {
+				auth.OptionalAuth(authHandler)(r)
+				r.Get("/hashtags/{tag}/posts", hashtagHandler.ListPosts)
+			})
+
+			searchService := search.NewService(repository, postService)
+			searchHandler := search.NewHandler(searchService)
+
+			r.Group(func(r chi.Router) {
+				auth.OptionalAuth(authHandler)(r)
+				r.Get("/search", searchHandler.Search)
+			})
+
+			commentService := comment.NewService(repository, db, reactionService)
 			commentHandler := comment.NewHandler(commentService)
-			r.Get("/posts/{post_id}/comments", commentHandler.ListCommentsByPostID)
-			r.Get("/comments/{id}", commentHandler.GetComment)
+
+			r.Group(func(r chi.Router) {
+				auth.OptionalAuth(authHandler)(r)
+				r.Get("/posts/{post_id}/comments", commentHandler.ListCommentsByPostID)
+				r.Get("/comments/{id}", commentHandler.GetComment)
+				r.Get("/comments/{id}/replies", commentHandler.ListReplies)
+				r.Get("/comments/{id}/thread", commentHandler.GetThread)
+				r.Get("/comments/{id}/revisions", commentHandler.ListRevisions)
+			})
 
 			r.Group(func(r chi.Router) {
 				auth.RequireAuth(authHandler)(r)
 				r.Post("/posts/{post_id}/comments", commentHandler.CreateComment)
 				r.Put("/comments/{id}", commentHandler.UpdateComment)
 				r.Delete("/comments/{id}", commentHandler.DeleteComment)
+				r.Post("/comments/{id}/restore", commentHandler.RestoreComment)
+				r.Post("/comments/{id}/hide", commentHandler.HideComment)
+				r.Delete("/comments/{id}/hide", commentHandler.UnhideComment)
+				r.Post("/comments/{id}/revisions/{revision_id}/restore", commentHandler.RestoreRevision)
+			})
+
+			trashService := trash.NewService(repository)
+			trashHandler := trash.NewHandler(trashService)
+			app.workers = append(app.workers, trash.NewPurger(repository, time.Hour))
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Get("/users/me/trash", trashHandler.ListTrash)
+			})
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				auth.RequireModerator(authHandler)(r)
+				r.Get("/moderation/posts/{id}", postHandler.GetPostIncludingDeleted)
+				r.Put("/moderation/posts/{id}/flags", postHandler.FlagPost)
+				r.Get("/moderation/posts/{post_id}/comments", commentHandler.ListCommentsByPostIDIncludingDeleted)
+				r.Get("/moderation/comments/{id}", commentHandler.GetCommentIncludingDeleted)
 			})
 
 			followService := follow.NewService(repository)
@@ -146,22 +278,110 @@ func (app *application) mount() http.Handler {
 				r.Delete("/users/{user_id}/follow", followHandler.UnfollowUser)
 			})
 
-			feedService := feed.NewService(repository)
+			blockService := block.NewService(repository)
+			blockHandler := block.NewHandler(blockService)
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Get("/users/me/blocks", blockHandler.ListBlockedUsers)
+				r.Post("/users/{user_id}/block", blockHandler.BlockUser)
+				r.Delete("/users/{user_id}/block", blockHandler.UnblockUser)
+			})
+
+			muteService := mute.NewService(repository)
+			muteHandler := mute.NewHandler(muteService)
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Get("/users/me/mutes", muteHandler.ListMutedUsers)
+				r.Post("/users/{user_id}/mute", muteHandler.MuteUser)
+				r.Delete("/users/{user_id}/mute", muteHandler.UnmuteUser)
+			})
+
+			mentionService := mention.NewService(repository)
+			mentionHandler := mention.NewHandler(mentionService)
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Get("/users/me/mentions", mentionHandler.ListMentions)
+			})
+
+			notificationService := notification.NewService(repository)
+			notificationHandler := notification.NewHandler(notificationService)
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Get("/users/me/notifications", notificationHandler.ListNotifications)
+				r.Post("/users/me/notifications/read", notificationHandler.MarkAllRead)
+			})
+
+			feedService := feed.NewService(repository, postService, impressions)
 			feedHandler := feed.NewHandler(feedService)
+			app.workers = append(app.workers, feed.NewFanout(repository, 5*time.Second))
+			app.workers = append(app.workers, feed.NewExplorer(repository, db, 5*time.Minute))
+
+			r.Group(func(r chi.Router) {
+				auth.OptionalAuth(authHandler)(r)
+				r.Get("/feed/explore", feedHandler.GetExplore)
+			})
 
 			r.Group(func(r chi.Router) {
 				auth.RequireAuth(authHandler)(r)
 				r.Get("/feed", feedHandler.GetFeed)
 			})
 
-			likeService := like.NewService(repository)
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				auth.RequireModerator(authHandler)(r)
+				r.Get("/moderation/feed/rankings", feedHandler.ListRankings)
+				r.Put("/moderation/feed/rankings/{ranking}", feedHandler.UpdateRanking)
+			})
+
+			bookmarkService := bookmark.NewService(repository, postService)
+			bookmarkHandler := bookmark.NewHandler(bookmarkService)
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Get("/users/me/bookmarks", bookmarkHandler.ListBookmarks)
+				r.Put("/posts/{post_id}/bookmark", bookmarkHandler.BookmarkPost)
+				r.Delete("/posts/{post_id}/bookmark", bookmarkHandler.DeleteBookmark)
+				r.Get("/users/me/bookmark-collections", bookmarkHandler.ListCollections)
+				r.Post("/users/me/bookmark-collections", bookmarkHandler.CreateCollection)
+				r.Put("/users/me/bookmark-collections/order", bookmarkHandler.ReorderCollections)
+				r.Put("/users/me/bookmark-collections/{id}", bookmarkHandler.RenameCollection)
+				r.Delete("/users/me/bookmark-collections/{id}", bookmarkHandler.DeleteCollection)
+			})
+
+			likeService := like.NewService(repository, postService)
 			likeHandler := like.NewHandler(likeService)
-			r.Get("/posts/{post_id}/likes", likeHandler.ListLikesByPostID)
+
+			r.Group(func(r chi.Router) {
+				auth.OptionalAuth(authHandler)(r)
+				r.Get("/posts/{post_id}/likes", likeHandler.ListLikesByPostID)
+				r.Get("/users/{user_id}/likes", likeHandler.ListLikedPosts)
+				r.Get("/posts/{post_id}/reactions", reactionHandler.ListPostReactions)
+				r.Get("/reactions", reactionHandler.ListTypes)
+			})
 
 			r.Group(func(r chi.Router) {
 				auth.RequireAuth(authHandler)(r)
 				r.Post("/posts/{post_id}/like", likeHandler.LikePost)
 				r.Delete("/posts/{post_id}/like", likeHandler.UnlikePost)
+				r.Post("/comments/{id}/like", likeHandler.LikeComment)
+				r.Delete("/comments/{id}/like", likeHandler.UnlikeComment)
+				r.Put("/posts/{post_id}/reactions", reactionHandler.ReactToPost)
+				r.Delete("/posts/{post_id}/reactions", reactionHandler.RemovePostReaction)
+				r.Put("/comments/{id}/reactions", reactionHandler.ReactToComment)
+				r.Delete("/comments/{id}/reactions", reactionHandler.RemoveCommentReaction)
+			})
+
+			repostService := repost.NewService(repository)
+			repostHandler := repost.NewHandler(repostService)
+
+			r.Group(func(r chi.Router) {
+				auth.RequireAuth(authHandler)(r)
+				r.Post("/posts/{post_id}/repost", repostHandler.RepostPost)
+				r.Delete("/posts/{post_id}/repost", repostHandler.UndoRepost)
 			})
 
 			r.Group(func(r chi.Router) {
@@ -177,6 +397,18 @@ func (app *application) mount() http.Handler {
 	return r
 }
 
+// noDirListing hides the directory index http.FileServer would otherwise
+// render for paths ending in a slash.
+func noDirListing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (app *application) run(h http.Handler) error {
 	app.server = &http.Server{
 		Addr:         app.config.addr,
@@ -191,12 +423,28 @@ func (app *application) run(h http.Handler) error {
 	return app.server.ListenAndServe()
 }
 
+func (app *application) startWorkers(ctx context.Context) {
+	ctx, app.stop = context.WithCancel(ctx)
+
+	for _, w := range app.workers {
+		app.wg.Add(1)
+		go func() {
+			defer app.wg.Done()
+			w.Run(ctx)
+		}()
+	}
+}
+
 func (app *application) shutdown(ctx context.Context) error {
 	slog.Info("shutting down http server...")
 	if err := app.server.Shutdown(ctx); err != nil {
 		return err
 	}
 
+	slog.Info("stopping background workers...")
+	app.stop()
+	app.wg.Wait()
+
 	slog.Info("closing database connection...")
 	app.db.Close()
 
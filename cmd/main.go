@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/internal/media"
 	"github.com/etherealsense/social-network/pkg/env"
+	"github.com/etherealsense/social-network/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
@@ -40,6 +42,12 @@ func main() {
 			RefreshTokenTTL: time.Duration(env.GetInt("JWT_REFRESH_TOKEN_TTL")) * time.Hour,
 			CookieSecure:    env.GetBool("COOKIE_SECURE"),
 		},
+		media: mediaConfig{
+			dir:     env.GetString("MEDIA_DIR"),
+			baseURL: env.GetString("MEDIA_BASE_URL"),
+		},
+		reactions:    strings.Split(env.GetString("REACTION_TYPES"), ","),
+		cursorSecret: env.GetString("CURSOR_SECRET"),
 	}
 
 	var handler slog.Handler
@@ -54,17 +62,28 @@ func main() {
 	}
 	slog.SetDefault(slog.New(handler))
 
+	if err := pagination.SetSecret([]byte(cfg.cursorSecret)); err != nil {
+		panic(err)
+	}
+
 	pool, err := pgxpool.New(ctx, cfg.db.dsn)
 	if err != nil {
 		panic(err)
 	}
 
+	storage, err := media.NewLocalStorage(cfg.media.dir, cfg.media.baseURL)
+	if err != nil {
+		panic(err)
+	}
+
 	app := &application{
-		config: cfg,
-		db:     pool,
+		config:  cfg,
+		db:      pool,
+		storage: storage,
 	}
 
 	h := app.mount()
+	app.startWorkers(ctx)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
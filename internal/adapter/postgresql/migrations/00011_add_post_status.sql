@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+  ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published',
+  ADD COLUMN publish_at TIMESTAMPTZ,
+  ADD CONSTRAINT posts_status_check CHECK (status IN ('draft', 'scheduled', 'published')),
+  ADD CONSTRAINT posts_scheduled_publish_at_check CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+UPDATE posts SET publish_at = created_at;
+
+CREATE INDEX idx_posts_scheduled ON posts(publish_at) WHERE status = 'scheduled';
+CREATE INDEX idx_posts_user_id_status ON posts(user_id, status, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_posts_user_id_status;
+DROP INDEX IF EXISTS idx_posts_scheduled;
+
+ALTER TABLE posts
+  DROP CONSTRAINT IF EXISTS posts_scheduled_publish_at_check,
+  DROP CONSTRAINT IF EXISTS posts_status_check,
+  DROP COLUMN IF EXISTS publish_at,
+  DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
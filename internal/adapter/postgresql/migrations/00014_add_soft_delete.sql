@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_posts_deleted_at ON posts(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_comments_deleted_at ON comments(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
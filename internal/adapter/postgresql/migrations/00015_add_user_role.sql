@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+  ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user',
+  ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_role_check,
+  DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
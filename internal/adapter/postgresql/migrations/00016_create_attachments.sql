@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS attachments (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id INTEGER REFERENCES posts(id) ON DELETE SET NULL,
+  position INTEGER NOT NULL DEFAULT 0,
+  kind VARCHAR(10) NOT NULL,
+  mime_type VARCHAR(100) NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  width INTEGER,
+  height INTEGER,
+  storage_key VARCHAR(255) NOT NULL,
+  blurhash VARCHAR(100),
+  alt_text TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT attachments_kind_check CHECK (kind IN ('image', 'video'))
+);
+
+CREATE INDEX idx_attachments_post_id ON attachments(post_id, position);
+CREATE INDEX idx_attachments_orphaned ON attachments(created_at) WHERE post_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attachments;
+-- +goose StatementEnd
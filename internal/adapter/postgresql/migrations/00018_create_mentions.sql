@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+  ADD COLUMN handle VARCHAR(30),
+  ADD COLUMN mention_policy VARCHAR(20) NOT NULL DEFAULT 'everyone',
+  ADD CONSTRAINT users_mention_policy_check CHECK (mention_policy IN ('everyone', 'following', 'nobody'));
+
+UPDATE users SET handle = 'user' || id;
+
+ALTER TABLE users ALTER COLUMN handle SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_users_handle ON users(handle);
+
+CREATE TABLE IF NOT EXISTS blocks (
+  blocker_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  blocked_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (blocker_id, blocked_id),
+  CONSTRAINT blocks_self_check CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_blocks_blocked_id ON blocks(blocked_id);
+
+CREATE TABLE IF NOT EXISTS mentions (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id INTEGER REFERENCES posts(id) ON DELETE CASCADE,
+  comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+  message_id INTEGER REFERENCES messages(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT mentions_source_check CHECK (num_nonnulls(post_id, comment_id, message_id) = 1)
+);
+
+CREATE INDEX idx_mentions_user_id ON mentions(user_id, created_at DESC);
+CREATE UNIQUE INDEX idx_mentions_post_id ON mentions(post_id, user_id) WHERE post_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_mentions_comment_id ON mentions(comment_id, user_id) WHERE comment_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_mentions_message_id ON mentions(message_id, user_id) WHERE message_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS mentions;
+DROP TABLE IF EXISTS blocks;
+DROP INDEX IF EXISTS idx_users_handle;
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_mention_policy_check,
+  DROP COLUMN IF EXISTS mention_policy,
+  DROP COLUMN IF EXISTS handle;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reposts (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (user_id, post_id)
+);
+
+CREATE INDEX idx_reposts_post_id ON reposts(post_id);
+
+-- No foreign key on purpose: a quote keeps pointing at its original after
+-- the original is purged so that it can be rendered as a tombstone.
+ALTER TABLE posts ADD COLUMN quote_post_id INTEGER;
+
+CREATE INDEX idx_posts_quote_post_id ON posts(quote_post_id) WHERE quote_post_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts DROP COLUMN IF EXISTS quote_post_id;
+DROP TABLE IF EXISTS reposts;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+  ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+  ADD CONSTRAINT posts_visibility_check CHECK (visibility IN ('public', 'followers', 'mentioned', 'private'));
+
+-- can_view_post is the single definition of who may read a post. A viewer id
+-- of 0 stands for an anonymous request.
+CREATE OR REPLACE FUNCTION can_view_post(post_id INTEGER, author_id INTEGER, visibility VARCHAR, viewer_id INTEGER)
+RETURNS BOOLEAN
+LANGUAGE sql
+STABLE
+AS $$
+  SELECT visibility = 'public'
+    OR author_id = viewer_id
+    OR (visibility = 'followers' AND EXISTS (
+      SELECT 1 FROM follows f WHERE f.follower_id = viewer_id AND f.following_id = author_id
+    ))
+    OR (visibility = 'mentioned' AND EXISTS (
+      SELECT 1 FROM mentions m WHERE m.post_id = can_view_post.post_id AND m.user_id = viewer_id
+    ))
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS can_view_post(INTEGER, INTEGER, VARCHAR, INTEGER);
+ALTER TABLE posts
+  DROP CONSTRAINT IF EXISTS posts_visibility_check,
+  DROP COLUMN IF EXISTS visibility;
+-- +goose StatementEnd
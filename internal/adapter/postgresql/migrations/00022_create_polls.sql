@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS polls (
+  id SERIAL PRIMARY KEY,
+  post_id INTEGER NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+  multiple BOOLEAN NOT NULL DEFAULT FALSE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  closed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (id, multiple)
+);
+
+CREATE INDEX idx_polls_expires_at ON polls(expires_at) WHERE closed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS poll_options (
+  id SERIAL PRIMARY KEY,
+  poll_id INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  text VARCHAR(100) NOT NULL,
+  UNIQUE (poll_id, position),
+  UNIQUE (poll_id, id)
+);
+
+-- multiple is copied from the poll and pinned to it by the foreign key, so
+-- the partial unique index below can allow a single vote per user on
+-- single-choice polls while multiple-choice polls get one row per option.
+CREATE TABLE IF NOT EXISTS poll_votes (
+  poll_id INTEGER NOT NULL,
+  option_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  multiple BOOLEAN NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (poll_id, user_id, option_id),
+  FOREIGN KEY (poll_id, multiple) REFERENCES polls(id, multiple) ON DELETE CASCADE,
+  FOREIGN KEY (poll_id, option_id) REFERENCES poll_options(poll_id, id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_poll_votes_single ON poll_votes(poll_id, user_id) WHERE NOT multiple;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  position INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (user_id, name)
+);
+
+-- Bookmarks of a deleted collection fall back to the default, unnamed list.
+-- Bookmarks of soft-deleted posts are kept but filtered out, so they come
+-- back if the post is restored and go away with it when it is purged.
+CREATE TABLE IF NOT EXISTS bookmarks (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  collection_id INTEGER REFERENCES bookmark_collections(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (user_id, post_id)
+);
+
+CREATE INDEX idx_bookmarks_user_id ON bookmarks(user_id, id DESC);
+CREATE INDEX idx_bookmarks_post_id ON bookmarks(post_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bookmarks;
+DROP TABLE IF EXISTS bookmark_collections;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- content keeps the CommonMark source, content_html the sanitised rendering
+-- made on write. Rows written before this migration are rendered on read
+-- until they are next edited.
+ALTER TABLE posts ADD COLUMN content_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN content_html TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE comments DROP COLUMN IF EXISTS content_html;
+ALTER TABLE posts DROP COLUMN IF EXISTS content_html;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Previews are cached by URL and shared by every post linking to it. status
+-- moves from pending to fetching to ready or failed; the worker picks up
+-- pending rows and fetching rows whose claim has gone stale.
+CREATE TABLE IF NOT EXISTS link_previews (
+  url TEXT PRIMARY KEY,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'fetching', 'ready', 'failed')),
+  title TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  image_url TEXT NOT NULL DEFAULT '',
+  site_name TEXT NOT NULL DEFAULT '',
+  claimed_at TIMESTAMPTZ,
+  fetched_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_link_previews_pending ON link_previews(created_at) WHERE status IN ('pending', 'fetching');
+
+CREATE TABLE IF NOT EXISTS post_links (
+  post_id INTEGER PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+  url TEXT NOT NULL REFERENCES link_previews(url) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_post_links_url ON post_links(url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS post_links;
+DROP TABLE IF EXISTS link_previews;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+  ADD COLUMN content_warning VARCHAR(200) NOT NULL DEFAULT '',
+  ADD COLUMN sensitive BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- sensitive_content decides whether posts with a content warning or
+-- sensitive media are collapsed for the user.
+ALTER TABLE users
+  ADD COLUMN sensitive_content VARCHAR(10) NOT NULL DEFAULT 'hide',
+  ADD CONSTRAINT users_sensitive_content_check CHECK (sensitive_content IN ('hide', 'expand'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_sensitive_content_check,
+  DROP COLUMN IF EXISTS sensitive_content;
+
+ALTER TABLE posts
+  DROP COLUMN IF EXISTS sensitive,
+  DROP COLUMN IF EXISTS content_warning;
+-- +goose StatementEnd
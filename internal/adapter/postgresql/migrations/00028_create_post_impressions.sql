@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- post_impressions deduplicates views: a viewer counts once per post per
+-- window. Rows of past windows are only kept until the next flush.
+CREATE TABLE IF NOT EXISTS post_impressions (
+  post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  viewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  window_start TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (post_id, viewer_id, window_start)
+);
+
+CREATE INDEX idx_post_impressions_window_start ON post_impressions(window_start);
+
+CREATE TABLE IF NOT EXISTS post_view_counts (
+  post_id INTEGER PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+  views_count BIGINT NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS post_view_counts;
+DROP TABLE IF EXISTS post_impressions;
+-- +goose StatementEnd
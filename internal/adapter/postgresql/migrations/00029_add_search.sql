@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- language is the text search configuration a row is indexed with. It is a
+-- column rather than a constant so that the generated vectors stay
+-- immutable while posts in different languages are stemmed correctly.
+ALTER TABLE posts ADD COLUMN language REGCONFIG NOT NULL DEFAULT 'english';
+
+ALTER TABLE posts ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  setweight(to_tsvector(language, title), 'A') ||
+  setweight(to_tsvector(language, content), 'B')
+) STORED;
+
+CREATE INDEX idx_posts_search_vector ON posts USING GIN (search_vector);
+
+ALTER TABLE comments ADD COLUMN language REGCONFIG NOT NULL DEFAULT 'english';
+
+ALTER TABLE comments ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  to_tsvector(language, content)
+) STORED;
+
+CREATE INDEX idx_comments_search_vector ON comments USING GIN (search_vector);
+
+-- Names and handles are not stemmed.
+CREATE INDEX idx_users_search ON users USING GIN (to_tsvector('simple', name || ' ' || handle));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_search;
+
+ALTER TABLE comments
+  DROP COLUMN IF EXISTS search_vector,
+  DROP COLUMN IF EXISTS language;
+
+ALTER TABLE posts
+  DROP COLUMN IF EXISTS search_vector,
+  DROP COLUMN IF EXISTS language;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A reply must belong to the same post as its parent, which the composite
+-- foreign key enforces. depth is 0 for top-level comments.
+ALTER TABLE comments
+  ADD CONSTRAINT comments_post_id_id_key UNIQUE (post_id, id),
+  ADD COLUMN parent_id INTEGER,
+  ADD COLUMN depth INTEGER NOT NULL DEFAULT 0,
+  ADD CONSTRAINT comments_parent_fkey FOREIGN KEY (post_id, parent_id) REFERENCES comments(post_id, id) ON DELETE CASCADE,
+  ADD CONSTRAINT comments_depth_check CHECK ((parent_id IS NULL) = (depth = 0));
+
+CREATE INDEX idx_comments_parent_id ON comments(parent_id, id) WHERE parent_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_comments_parent_id;
+
+ALTER TABLE comments
+  DROP CONSTRAINT IF EXISTS comments_depth_check,
+  DROP CONSTRAINT IF EXISTS comments_parent_fkey,
+  DROP COLUMN IF EXISTS depth,
+  DROP COLUMN IF EXISTS parent_id,
+  DROP CONSTRAINT IF EXISTS comments_post_id_id_key;
+-- +goose StatementEnd
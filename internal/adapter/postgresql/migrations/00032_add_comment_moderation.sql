@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- comment_policy decides who besides the author may comment on a post.
+-- comments_locked stops new comments from everyone but the author.
+ALTER TABLE posts
+  ADD COLUMN comments_locked BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN comment_policy VARCHAR(10) NOT NULL DEFAULT 'everyone',
+  ADD CONSTRAINT posts_comment_policy_check CHECK (comment_policy IN ('everyone', 'followers', 'mentioned'));
+
+-- Hidden comments stay visible to their author and the post author only.
+-- deleted_by records who removed a comment, so a comment removed by the
+-- post author cannot be restored by its own author.
+ALTER TABLE comments
+  ADD COLUMN hidden_at TIMESTAMPTZ,
+  ADD COLUMN deleted_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE comments
+  DROP COLUMN IF EXISTS deleted_by,
+  DROP COLUMN IF EXISTS hidden_at;
+
+ALTER TABLE posts
+  DROP CONSTRAINT IF EXISTS posts_comment_policy_check,
+  DROP COLUMN IF EXISTS comment_policy,
+  DROP COLUMN IF EXISTS comments_locked;
+-- +goose StatementEnd
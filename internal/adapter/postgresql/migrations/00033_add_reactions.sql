@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- likes and comment_likes now hold one reaction per user and post or
+-- comment. Existing rows become "like" reactions. The set of reactions is
+-- configured in the application, so it is not checked here.
+ALTER TABLE likes
+  ADD COLUMN reaction VARCHAR(20) NOT NULL DEFAULT 'like';
+
+ALTER TABLE comment_likes
+  ADD COLUMN reaction VARCHAR(20) NOT NULL DEFAULT 'like';
+
+CREATE INDEX idx_likes_post_id_reaction ON likes(post_id, reaction, created_at DESC);
+CREATE INDEX idx_comment_likes_comment_id_reaction ON comment_likes(comment_id, reaction);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_comment_likes_comment_id_reaction;
+DROP INDEX IF EXISTS idx_likes_post_id_reaction;
+
+-- Only likes can be represented without the reaction column.
+DELETE FROM comment_likes WHERE reaction <> 'like';
+DELETE FROM likes WHERE reaction <> 'like';
+
+ALTER TABLE comment_likes DROP COLUMN IF EXISTS reaction;
+ALTER TABLE likes DROP COLUMN IF EXISTS reaction;
+-- +goose StatementEnd
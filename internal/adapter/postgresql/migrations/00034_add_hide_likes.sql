@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- hide_likes keeps the posts a user has liked out of their public profile.
+ALTER TABLE users
+  ADD COLUMN hide_likes BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+  DROP COLUMN IF EXISTS hide_likes;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- timelines materializes the home feed: a post is written to the timeline of
+-- every follower of its author once, when it is published, instead of being
+-- collected from follows on every read. created_at is when the post was
+-- published. Entries older than the feed's retention are pruned.
+CREATE TABLE IF NOT EXISTS timelines (
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX idx_timelines_user_id_author_id ON timelines(user_id, author_id);
+CREATE INDEX idx_timelines_created_at ON timelines(created_at);
+
+-- timeline_fanout queues published posts for the fan-out worker, which
+-- claims rows the same way the link preview worker does.
+CREATE TABLE IF NOT EXISTS timeline_fanout (
+  post_id INTEGER PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+  claimed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Posts of authors with too many followers to copy each post to are not
+-- fanned out; the feed merges them in when it is read instead.
+ALTER TABLE users ADD COLUMN fanout_on_read BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO timelines (user_id, post_id, author_id, created_at)
+SELECT f.follower_id, p.id, p.user_id, COALESCE(p.publish_at, p.created_at)
+FROM posts p
+JOIN follows f ON f.following_id = p.user_id
+WHERE p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND COALESCE(p.publish_at, p.created_at) > NOW() - INTERVAL '30 days';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS fanout_on_read;
+DROP TABLE IF EXISTS timeline_fanout;
+DROP TABLE IF EXISTS timelines;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- feed_ranking is how the user's feed is ordered unless a request asks for
+-- another ranking.
+ALTER TABLE users
+  ADD COLUMN feed_ranking VARCHAR(20) NOT NULL DEFAULT 'weighted',
+  ADD CONSTRAINT users_feed_ranking_check CHECK (feed_ranking IN ('chronological', 'weighted', 'decayed'));
+
+-- Weights of the rankings that take any. They are read on every feed
+-- request, so moderators can tune them without a deploy. The seeded values
+-- reproduce the formula the feed was ranked with before.
+CREATE TABLE IF NOT EXISTS feed_ranking_weights (
+  ranking VARCHAR(20) PRIMARY KEY CHECK (ranking IN ('weighted', 'decayed')),
+  likes DOUBLE PRECISION NOT NULL CHECK (likes >= 0),
+  comments DOUBLE PRECISION NOT NULL CHECK (comments >= 0),
+  age DOUBLE PRECISION NOT NULL CHECK (age >= 0),
+  gravity DOUBLE PRECISION NOT NULL CHECK (gravity >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO feed_ranking_weights (ranking, likes, comments, age, gravity) VALUES
+  ('weighted', 0.5, 2, 1, 0),
+  ('decayed', 0.5, 2, 0, 1.8);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS feed_ranking_weights;
+
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_feed_ranking_check,
+  DROP COLUMN IF EXISTS feed_ranking;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- mutes hide a user's posts from the muter without the user knowing, unlike
+-- blocks, which also cut off the other direction.
+CREATE TABLE IF NOT EXISTS mutes (
+  muter_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  muted_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (muter_id, muted_id),
+  CONSTRAINT mutes_self_check CHECK (muter_id <> muted_id)
+);
+
+CREATE INDEX idx_mutes_muted_id ON mutes(muted_id);
+
+-- explore_posts holds snapshots of the explore feed, one per computed_at.
+-- The explore worker writes a new snapshot periodically and drops old ones
+-- once their cursors have had time to expire; readers use the latest.
+CREATE TABLE IF NOT EXISTS explore_posts (
+  computed_at TIMESTAMPTZ NOT NULL,
+  post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  score DOUBLE PRECISION NOT NULL,
+  PRIMARY KEY (computed_at, post_id)
+);
+
+CREATE INDEX idx_explore_posts_score ON explore_posts(computed_at, score DESC, post_id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS explore_posts;
+DROP TABLE IF EXISTS mutes;
+-- +goose StatementEnd
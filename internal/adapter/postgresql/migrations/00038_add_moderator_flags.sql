@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Flags set by a moderator live apart from the author's own content warning
+-- and sensitive flag, so editing a post can't clear them.
+ALTER TABLE posts
+  ADD COLUMN moderator_content_warning VARCHAR(200) NOT NULL DEFAULT '',
+  ADD COLUMN moderator_sensitive BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts
+  DROP COLUMN IF EXISTS moderator_sensitive,
+  DROP COLUMN IF EXISTS moderator_content_warning;
+-- +goose StatementEnd
@@ -64,18 +64,35 @@ const listChatsByUserID = `-- name: ListChatsByUserID :many
 SELECT c.id, c.created_at FROM chats c
 JOIN chat_participants cp ON cp.chat_id = c.id
 WHERE cp.user_id = $1
-ORDER BY c.created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (c.created_at, c.id) < ($2::timestamptz, $4::int))
+    OR ($3::bool AND (c.created_at, c.id) > ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN c.created_at END,
+    CASE WHEN $3::bool THEN c.id END,
+    c.created_at DESC,
+    c.id DESC
+LIMIT $5 OFFSET $6
 `
 
 type ListChatsByUserIDParams struct {
-	UserID int32 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID     int32              `json:"user_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
 }
 
 func (q *Queries) ListChatsByUserID(ctx context.Context, arg ListChatsByUserIDParams) ([]Chat, error) {
-	rows, err := q.db.Query(ctx, listChatsByUserID, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listChatsByUserID,
+		arg.UserID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -57,17 +57,37 @@ func (q *Queries) GetChatParticipantByChatIDAndUserID(ctx context.Context, arg G
 }
 
 const listChatParticipantsByChatID = `-- name: ListChatParticipantsByChatID :many
-SELECT chat_id, user_id, joined_at FROM chat_participants WHERE chat_id = $1 ORDER BY joined_at LIMIT $2 OFFSET $3
+SELECT chat_id, user_id, joined_at FROM chat_participants
+WHERE chat_id = $1
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (joined_at, user_id) > ($2::timestamptz, $4::int))
+    OR ($3::bool AND (joined_at, user_id) < ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN joined_at END DESC,
+    CASE WHEN $3::bool THEN user_id END DESC,
+    joined_at,
+    user_id
+LIMIT $5 OFFSET $6
 `
 
 type ListChatParticipantsByChatIDParams struct {
-	ChatID int32 `json:"chat_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	ChatID     int32              `json:"chat_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
 }
 
 func (q *Queries) ListChatParticipantsByChatID(ctx context.Context, arg ListChatParticipantsByChatIDParams) ([]ChatParticipant, error) {
-	rows, err := q.db.Query(ctx, listChatParticipantsByChatID, arg.ChatID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listChatParticipantsByChatID,
+		arg.ChatID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -12,7 +12,7 @@ import (
 )
 
 const countCommentsByPostID = `-- name: CountCommentsByPostID :one
-SELECT COUNT(*) FROM comments WHERE post_id = $1
+SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL AND hidden_at IS NULL
 `
 
 func (q *Queries) CountCommentsByPostID(ctx context.Context, postID int32) (int64, error) {
@@ -22,18 +22,72 @@ func (q *Queries) CountCommentsByPostID(ctx context.Context, postID int32) (int6
 	return count, err
 }
 
+const countRepliesByCommentIDs = `-- name: CountRepliesByCommentIDs :many
+SELECT c.parent_id::int AS comment_id, COUNT(*) AS count
+FROM comments c
+JOIN posts p ON p.id = c.post_id
+WHERE c.parent_id = ANY($1::int[]) AND c.deleted_at IS NULL
+  AND (c.hidden_at IS NULL OR c.user_id = $2 OR p.user_id = $2)
+GROUP BY c.parent_id
+`
+
+type CountRepliesByCommentIDsParams struct {
+	CommentIds []int32 `json:"comment_ids"`
+	ViewerID   int32   `json:"viewer_id"`
+}
+
+type CountRepliesByCommentIDsRow struct {
+	CommentID int32 `json:"comment_id"`
+	Count     int64 `json:"count"`
+}
+
+func (q *Queries) CountRepliesByCommentIDs(ctx context.Context, arg CountRepliesByCommentIDsParams) ([]CountRepliesByCommentIDsRow, error) {
+	rows, err := q.db.Query(ctx, countRepliesByCommentIDs, arg.CommentIds, arg.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountRepliesByCommentIDsRow
+	for rows.Next() {
+		var i CountRepliesByCommentIDsRow
+		if err := rows.Scan(&i.CommentID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createComment = `-- name: CreateComment :one
-INSERT INTO comments (post_id, user_id, content) VALUES ($1, $2, $3) RETURNING id, post_id, user_id, content, created_at, updated_at
+INSERT INTO comments (post_id, user_id, content, language, parent_id, depth)
+VALUES (
+    $1, $2, $3,
+    COALESCE($1::regconfig, (SELECT language FROM posts WHERE id = $1)),
+    $2,
+    COALESCE((SELECT depth + 1 FROM comments WHERE id = $2), 0)
+)
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
 `
 
 type CreateCommentParams struct {
-	PostID  int32  `json:"post_id"`
-	UserID  int32  `json:"user_id"`
-	Content string `json:"content"`
+	PostID   int32       `json:"post_id"`
+	UserID   int32       `json:"user_id"`
+	Content  string      `json:"content"`
+	Language pgtype.Text `json:"language"`
+	ParentID pgtype.Int4 `json:"parent_id"`
 }
 
 func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
-	row := q.db.QueryRow(ctx, createComment, arg.PostID, arg.UserID, arg.Content)
+	row := q.db.QueryRow(ctx, createComment,
+		arg.PostID,
+		arg.UserID,
+		arg.Content,
+		arg.Language,
+		arg.ParentID,
+	)
 	var i Comment
 	err := row.Scan(
 		&i.ID,
@@ -42,21 +96,36 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const deleteComment = `-- name: DeleteComment :exec
-DELETE FROM comments WHERE id = $1
+UPDATE comments SET deleted_at = NOW(), deleted_by = $1 WHERE id = $2 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteComment(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteComment, id)
+type DeleteCommentParams struct {
+	DeletedBy pgtype.Int4 `json:"deleted_by"`
+	ID        int32       `json:"id"`
+}
+
+func (q *Queries) DeleteComment(ctx context.Context, arg DeleteCommentParams) error {
+	_, err := q.db.Exec(ctx, deleteComment, arg.DeletedBy, arg.ID)
 	return err
 }
 
 const findCommentByID = `-- name: FindCommentByID :one
-SELECT id, post_id, user_id, content, created_at, updated_at FROM comments WHERE id = $1
+SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+JOIN posts p ON p.id = c.post_id
+WHERE c.id = $1 AND c.deleted_at IS NULL AND p.deleted_at IS NULL
 `
 
 func (q *Queries) FindCommentByID(ctx context.Context, id int32) (Comment, error) {
@@ -69,22 +138,264 @@ func (q *Queries) FindCommentByID(ctx context.Context, id int32) (Comment, error
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
+const findCommentByIDIncludingDeleted = `-- name: FindCommentByIDIncludingDeleted :one
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by FROM comments WHERE id = $1
+`
+
+func (q *Queries) FindCommentByIDIncludingDeleted(ctx context.Context, id int32) (Comment, error) {
+	row := q.db.QueryRow(ctx, findCommentByIDIncludingDeleted, id)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.UserID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const hideComment = `-- name: HideComment :one
+UPDATE comments SET hidden_at = COALESCE(hidden_at, NOW()) WHERE id = $1
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+`
+
+func (q *Queries) HideComment(ctx context.Context, id int32) (Comment, error) {
+	row := q.db.QueryRow(ctx, hideComment, id)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.UserID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const listCommentSubtree = `-- name: ListCommentSubtree :many
+WITH RECURSIVE tree AS (
+    SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.parent_id = $1::int AND c.deleted_at IS NULL
+      AND (c.hidden_at IS NULL OR c.user_id = $2 OR p.user_id = $2)
+    UNION ALL
+    SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+    JOIN tree t ON c.parent_id = t.id
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.deleted_at IS NULL
+      AND (c.hidden_at IS NULL OR c.user_id = $2 OR p.user_id = $2)
+)
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+FROM tree
+ORDER BY depth, id
+LIMIT $3
+`
+
+type ListCommentSubtreeParams struct {
+	RootID   int32 `json:"root_id"`
+	ViewerID int32 `json:"viewer_id"`
+	Limit    int32 `json:"limit"`
+}
+
+func (q *Queries) ListCommentSubtree(ctx context.Context, arg ListCommentSubtreeParams) ([]Comment, error) {
+	rows, err := q.db.Query(ctx, listCommentSubtree, arg.RootID, arg.ViewerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.UserID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.ContentHtml,
+			&i.Language,
+			&i.ParentID,
+			&i.Depth,
+			&i.HiddenAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCommentsByPostID = `-- name: ListCommentsByPostID :many
-SELECT id, post_id, user_id, content, created_at, updated_at FROM comments WHERE post_id = $1 ORDER BY created_at ASC LIMIT $2 OFFSET $3
+SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by, k.rank, k.tiebreak FROM comments c
+JOIN posts p ON p.id = c.post_id
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS likes_count FROM comment_likes cl
+    WHERE cl.comment_id = c.id AND cl.reaction = 'like' AND $1::text = 'top'
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS replies_count FROM comments rc
+    WHERE rc.parent_id = c.id AND rc.deleted_at IS NULL AND rc.hidden_at IS NULL AND $1::text = 'top'
+) r
+CROSS JOIN LATERAL (
+    SELECT
+        (CASE $1::text
+            WHEN 'top' THEN
+                l.likes_count * 0.5
+                + r.replies_count * 2
+                - EXTRACT(EPOCH FROM ($2::timestamptz - c.created_at)) / 3600
+            WHEN 'newest' THEN EXTRACT(EPOCH FROM c.created_at)
+            ELSE -EXTRACT(EPOCH FROM c.created_at)
+        END)::float8 AS rank,
+        (CASE WHEN $1::text IN ('top', 'newest') THEN c.id ELSE -c.id END)::int AS tiebreak
+) k
+WHERE c.post_id = $3 AND c.parent_id IS NULL AND c.deleted_at IS NULL AND p.deleted_at IS NULL
+  AND (c.hidden_at IS NULL OR c.user_id = $4 OR p.user_id = $4)
+  AND ($5::float8 IS NULL
+    OR (NOT $6::bool AND (k.rank, k.tiebreak) < ($5::float8, $7::int))
+    OR ($6::bool AND (k.rank, k.tiebreak) > ($5::float8, $7::int)))
+ORDER BY
+    CASE WHEN $6::bool THEN k.rank END,
+    CASE WHEN $6::bool THEN k.tiebreak END,
+    k.rank DESC,
+    k.tiebreak DESC
+LIMIT $8 OFFSET $9
 `
 
 type ListCommentsByPostIDParams struct {
-	PostID int32 `json:"post_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Sort        string             `json:"sort"`
+	AsOf        pgtype.Timestamptz `json:"as_of"`
+	PostID      int32              `json:"post_id"`
+	ViewerID    int32              `json:"viewer_id"`
+	CursorScore pgtype.Float8      `json:"cursor_score"`
+	Backward    bool               `json:"backward"`
+	CursorID    int32              `json:"cursor_id"`
+	Limit       int32              `json:"limit"`
+	Offset      int32              `json:"offset"`
+}
+
+type ListCommentsByPostIDRow struct {
+	Comment  Comment `json:"comment"`
+	Rank     float64 `json:"rank"`
+	Tiebreak int32   `json:"tiebreak"`
+}
+
+func (q *Queries) ListCommentsByPostID(ctx context.Context, arg ListCommentsByPostIDParams) ([]ListCommentsByPostIDRow, error) {
+	rows, err := q.db.Query(ctx, listCommentsByPostID,
+		arg.Sort,
+		arg.AsOf,
+		arg.PostID,
+		arg.ViewerID,
+		arg.CursorScore,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCommentsByPostIDRow
+	for rows.Next() {
+		var i ListCommentsByPostIDRow
+		if err := rows.Scan(
+			&i.Comment.ID,
+			&i.Comment.PostID,
+			&i.Comment.UserID,
+			&i.Comment.Content,
+			&i.Comment.CreatedAt,
+			&i.Comment.UpdatedAt,
+			&i.Comment.Edited,
+			&i.Comment.DeletedAt,
+			&i.Comment.ContentHtml,
+			&i.Comment.Language,
+			&i.Comment.ParentID,
+			&i.Comment.Depth,
+			&i.Comment.HiddenAt,
+			&i.Comment.DeletedBy,
+			&i.Rank,
+			&i.Tiebreak,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommentsByPostIDIncludingDeleted = `-- name: ListCommentsByPostIDIncludingDeleted :many
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by FROM comments
+WHERE post_id = $1
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (created_at, id) > ($2::timestamptz, $4::int))
+    OR ($3::bool AND (created_at, id) < ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN created_at END DESC,
+    CASE WHEN $3::bool THEN id END DESC,
+    created_at,
+    id
+LIMIT $5 OFFSET $6
+`
+
+type ListCommentsByPostIDIncludingDeletedParams struct {
+	PostID     int32              `json:"post_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
 }
 
-func (q *Queries) ListCommentsByPostID(ctx context.Context, arg ListCommentsByPostIDParams) ([]Comment, error) {
-	rows, err := q.db.Query(ctx, listCommentsByPostID, arg.PostID, arg.Limit, arg.Offset)
+func (q *Queries) ListCommentsByPostIDIncludingDeleted(ctx context.Context, arg ListCommentsByPostIDIncludingDeletedParams) ([]Comment, error) {
+	rows, err := q.db.Query(ctx, listCommentsByPostIDIncludingDeleted,
+		arg.PostID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +410,14 @@ func (q *Queries) ListCommentsByPostID(ctx context.Context, arg ListCommentsByPo
 			&i.Content,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.ContentHtml,
+			&i.Language,
+			&i.ParentID,
+			&i.Depth,
+			&i.HiddenAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -110,22 +429,296 @@ func (q *Queries) ListCommentsByPostID(ctx context.Context, arg ListCommentsByPo
 	return items, nil
 }
 
+const listReplies = `-- name: ListReplies :many
+SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+JOIN posts p ON p.id = c.post_id
+WHERE c.parent_id = $1::int
+  AND c.deleted_at IS NULL
+  AND (c.hidden_at IS NULL OR c.user_id = $2 OR p.user_id = $2)
+  AND ($3::int = 0
+    OR (NOT $4::bool AND c.id > $3::int)
+    OR ($4::bool AND c.id < $3::int))
+ORDER BY
+    CASE WHEN $4::bool THEN c.id END DESC,
+    c.id
+LIMIT $5 OFFSET $6
+`
+
+type ListRepliesParams struct {
+	ParentID int32 `json:"parent_id"`
+	ViewerID int32 `json:"viewer_id"`
+	CursorID int32 `json:"cursor_id"`
+	Backward bool  `json:"backward"`
+	Limit    int32 `json:"limit"`
+	Offset   int32 `json:"offset"`
+}
+
+func (q *Queries) ListReplies(ctx context.Context, arg ListRepliesParams) ([]Comment, error) {
+	rows, err := q.db.Query(ctx, listReplies,
+		arg.ParentID,
+		arg.ViewerID,
+		arg.CursorID,
+		arg.Backward,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.UserID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.ContentHtml,
+			&i.Language,
+			&i.ParentID,
+			&i.Depth,
+			&i.HiddenAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRepliesByParentIDs = `-- name: ListRepliesByParentIDs :many
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+FROM (
+    SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by, ROW_NUMBER() OVER (PARTITION BY c.parent_id ORDER BY c.id) AS n
+    FROM comments c
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.parent_id = ANY($1::int[]) AND c.deleted_at IS NULL
+      AND (c.hidden_at IS NULL OR c.user_id = $2 OR p.user_id = $2)
+) r
+WHERE r.n <= $3::int
+ORDER BY r.parent_id, r.id
+`
+
+type ListRepliesByParentIDsParams struct {
+	ParentIds        []int32 `json:"parent_ids"`
+	ViewerID         int32   `json:"viewer_id"`
+	RepliesPerParent int32   `json:"replies_per_parent"`
+}
+
+func (q *Queries) ListRepliesByParentIDs(ctx context.Context, arg ListRepliesByParentIDsParams) ([]Comment, error) {
+	rows, err := q.db.Query(ctx, listRepliesByParentIDs, arg.ParentIds, arg.ViewerID, arg.RepliesPerParent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.UserID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.ContentHtml,
+			&i.Language,
+			&i.ParentID,
+			&i.Depth,
+			&i.HiddenAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedCommentsByUserID = `-- name: ListTrashedCommentsByUserID :many
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by FROM comments
+WHERE user_id = $1 AND deleted_at IS NOT NULL AND (deleted_by IS NULL OR deleted_by = user_id)
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (deleted_at, 'comment'::text, id) < ($2::timestamptz, $4::text, $5::int))
+    OR ($3::bool AND (deleted_at, 'comment'::text, id) > ($2::timestamptz, $4::text, $5::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN deleted_at END,
+    CASE WHEN $3::bool THEN id END,
+    deleted_at DESC,
+    id DESC
+LIMIT $6 OFFSET $7
+`
+
+type ListTrashedCommentsByUserIDParams struct {
+	UserID     int32              `json:"user_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorType string             `json:"cursor_type"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
+}
+
+func (q *Queries) ListTrashedCommentsByUserID(ctx context.Context, arg ListTrashedCommentsByUserIDParams) ([]Comment, error) {
+	rows, err := q.db.Query(ctx, listTrashedCommentsByUserID,
+		arg.UserID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorType,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.UserID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.ContentHtml,
+			&i.Language,
+			&i.ParentID,
+			&i.Depth,
+			&i.HiddenAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedComments = `-- name: PurgeDeletedComments :execrows
+DELETE FROM comments WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedComments(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedComments, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreComment = `-- name: RestoreComment :one
+UPDATE comments SET deleted_at = NULL, deleted_by = NULL WHERE id = $1 RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+`
+
+func (q *Queries) RestoreComment(ctx context.Context, id int32) (Comment, error) {
+	row := q.db.QueryRow(ctx, restoreComment, id)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.UserID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const setCommentContentHTML = `-- name: SetCommentContentHTML :exec
+UPDATE comments SET content_html = $2 WHERE id = $1
+`
+
+type SetCommentContentHTMLParams struct {
+	ID          int32  `json:"id"`
+	ContentHtml string `json:"content_html"`
+}
+
+func (q *Queries) SetCommentContentHTML(ctx context.Context, arg SetCommentContentHTMLParams) error {
+	_, err := q.db.Exec(ctx, setCommentContentHTML, arg.ID, arg.ContentHtml)
+	return err
+}
+
+const unhideComment = `-- name: UnhideComment :one
+UPDATE comments SET hidden_at = NULL WHERE id = $1
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+`
+
+func (q *Queries) UnhideComment(ctx context.Context, id int32) (Comment, error) {
+	row := q.db.QueryRow(ctx, unhideComment, id)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.UserID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
 const updateComment = `-- name: UpdateComment :one
+WITH revision AS (
+    INSERT INTO comment_revisions (comment_id, content, created_at)
+    SELECT c.id, c.content, c.updated_at
+    FROM comments c
+    WHERE c.id = $1
+      AND c.content <> COALESCE($2, c.content)
+)
 UPDATE comments
 SET
-    content = COALESCE($1, content),
+    content = COALESCE($2, content),
+    edited = edited OR content <> COALESCE($2, content),
     updated_at = NOW()
-WHERE id = $2
-RETURNING id, post_id, user_id, content, created_at, updated_at
+WHERE id = $1
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
 `
 
 type UpdateCommentParams struct {
-	Content pgtype.Text `json:"content"`
 	ID      int32       `json:"id"`
+	Content pgtype.Text `json:"content"`
 }
 
 func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error) {
-	row := q.db.QueryRow(ctx, updateComment, arg.Content, arg.ID)
+	row := q.db.QueryRow(ctx, updateComment, arg.ID, arg.Content)
 	var i Comment
 	err := row.Scan(
 		&i.ID,
@@ -134,6 +727,14 @@ func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (C
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.ContentHtml,
+		&i.Language,
+		&i.ParentID,
+		&i.Depth,
+		&i.HiddenAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -11,43 +11,139 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const deleteExploreSnapshotsBefore = `-- name: DeleteExploreSnapshotsBefore :exec
+DELETE FROM explore_posts WHERE computed_at < $1
+`
+
+func (q *Queries) DeleteExploreSnapshotsBefore(ctx context.Context, computedAt pgtype.Timestamptz) error {
+	_, err := q.db.Exec(ctx, deleteExploreSnapshotsBefore, computedAt)
+	return err
+}
+
 const getFeed = `-- name: GetFeed :many
+WITH followed_reposts AS (
+    SELECT
+        r.post_id,
+        array_agg(r.user_id ORDER BY r.created_at DESC)::int[] AS reposted_by,
+        MAX(r.created_at)::timestamptz AS reposted_at
+    FROM reposts r
+    JOIN follows f ON f.following_id = r.user_id AND f.follower_id = $1
+    WHERE r.created_at <= $2
+      AND NOT EXISTS (
+        SELECT 1 FROM mutes m WHERE m.muter_id = $1 AND m.muted_id = r.user_id
+      )
+    GROUP BY r.post_id
+), entries AS (
+    SELECT t.post_id FROM timelines t WHERE t.user_id = $1
+    UNION
+    SELECT p.id
+    FROM follows f
+    JOIN users u ON u.id = f.following_id AND u.fanout_on_read
+    JOIN posts p ON p.user_id = f.following_id
+    WHERE f.follower_id = $1
+      AND p.status = 'published'
+      AND p.deleted_at IS NULL
+      AND COALESCE(p.publish_at, p.created_at) > $3
+    UNION
+    SELECT post_id FROM followed_reposts
+)
 SELECT
-    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at,
-    COALESCE(l.likes_count, 0)::bigint AS likes_count,
-    COALESCE(c.comments_count, 0)::bigint AS comments_count,
-    (COALESCE(l.likes_count, 0) * 0.5
-     + COALESCE(c.comments_count, 0) * 2
-     - EXTRACT(EPOCH FROM (NOW() - p.created_at)) / 3600
-    )::float8 AS score
-FROM posts p
-JOIN follows f ON p.user_id = f.following_id AND f.follower_id = $1
-LEFT JOIN (SELECT post_id, COUNT(*) AS likes_count FROM likes GROUP BY post_id) l ON l.post_id = p.id
-LEFT JOIN (SELECT post_id, COUNT(*) AS comments_count FROM comments GROUP BY post_id) c ON c.post_id = p.id
-ORDER BY score DESC
-LIMIT $2 OFFSET $3
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive,
+    l.likes_count,
+    c.comments_count,
+    COALESCE(fr.reposted_by, '{}')::int[] AS reposted_by,
+    fr.reposted_at,
+    a.active_at,
+    s.score
+FROM entries e
+JOIN posts p ON p.id = e.post_id
+LEFT JOIN followed_reposts fr ON fr.post_id = p.id
+CROSS JOIN LATERAL (
+    SELECT GREATEST(COALESCE(p.publish_at, p.created_at), fr.reposted_at)::timestamptz AS active_at
+) a
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS likes_count FROM likes WHERE post_id = p.id AND reaction = 'like'
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS comments_count FROM comments
+    WHERE post_id = p.id AND deleted_at IS NULL AND hidden_at IS NULL
+) c
+CROSS JOIN LATERAL (
+    SELECT
+        $4::float8 * l.likes_count + $5::float8 * c.comments_count AS engagement,
+        GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - a.active_at)) / 3600, 0)::float8 AS hours
+) w
+CROSS JOIN LATERAL (
+    SELECT (CASE $6::text
+        WHEN 'weighted' THEN w.engagement - $7::float8 * w.hours
+        WHEN 'decayed' THEN (1 + w.engagement) / power(w.hours + 2, $8::float8)
+        ELSE EXTRACT(EPOCH FROM a.active_at)
+    END)::float8 AS score
+) s
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+  AND a.active_at <= $2
+  AND can_view_post(p.id, p.user_id, p.visibility, $1)
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = $1 AND b.blocked_id = p.user_id)
+       OR (b.blocker_id = p.user_id AND b.blocked_id = $1)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes m WHERE m.muter_id = $1 AND m.muted_id = p.user_id
+  )
+  AND ($9::float8 IS NULL
+    OR (NOT $10::bool AND (s.score, p.id) < ($9::float8, $11::int))
+    OR ($10::bool AND (s.score, p.id) > ($9::float8, $11::int)))
+ORDER BY
+    CASE WHEN $10::bool THEN s.score END,
+    CASE WHEN $10::bool THEN p.id END,
+    s.score DESC,
+    p.id DESC
+LIMIT $12 OFFSET $13
 `
 
 type GetFeedParams struct {
-	FollowerID int32 `json:"follower_id"`
-	Limit      int32 `json:"limit"`
-	Offset     int32 `json:"offset"`
+	FollowerID     int32              `json:"follower_id"`
+	AsOf           pgtype.Timestamptz `json:"as_of"`
+	Since          pgtype.Timestamptz `json:"since"`
+	LikesWeight    float64            `json:"likes_weight"`
+	CommentsWeight float64            `json:"comments_weight"`
+	Ranking        string             `json:"ranking"`
+	AgeWeight      float64            `json:"age_weight"`
+	Gravity        float64            `json:"gravity"`
+	CursorScore    pgtype.Float8      `json:"cursor_score"`
+	Backward       bool               `json:"backward"`
+	CursorID       int32              `json:"cursor_id"`
+	Limit          int32              `json:"limit"`
+	Offset         int32              `json:"offset"`
 }
 
 type GetFeedRow struct {
-	ID            int32              `json:"id"`
-	UserID        int32              `json:"user_id"`
-	Title         string             `json:"title"`
-	Content       string             `json:"content"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	Post          Post               `json:"post"`
 	LikesCount    int64              `json:"likes_count"`
 	CommentsCount int64              `json:"comments_count"`
+	RepostedBy    []int32            `json:"reposted_by"`
+	RepostedAt    pgtype.Timestamptz `json:"reposted_at"`
+	ActiveAt      pgtype.Timestamptz `json:"active_at"`
 	Score         float64            `json:"score"`
 }
 
 func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow, error) {
-	rows, err := q.db.Query(ctx, getFeed, arg.FollowerID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getFeed,
+		arg.FollowerID,
+		arg.AsOf,
+		arg.Since,
+		arg.LikesWeight,
+		arg.CommentsWeight,
+		arg.Ranking,
+		arg.AgeWeight,
+		arg.Gravity,
+		arg.CursorScore,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -56,15 +152,280 @@ func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow,
 	for rows.Next() {
 		var i GetFeedRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.Title,
-			&i.Content,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Content,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.Status,
+			&i.Post.PublishAt,
+			&i.Post.Edited,
+			&i.Post.DeletedAt,
+			&i.Post.QuotePostID,
+			&i.Post.Visibility,
+			&i.Post.ContentHtml,
+			&i.Post.ContentWarning,
+			&i.Post.Sensitive,
+			&i.Post.Language,
+			&i.Post.CommentsLocked,
+			&i.Post.CommentPolicy,
+			&i.Post.ModeratorContentWarning,
+			&i.Post.ModeratorSensitive,
 			&i.LikesCount,
 			&i.CommentsCount,
+			&i.RepostedBy,
+			&i.RepostedAt,
+			&i.ActiveAt,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertExploreSnapshot = `-- name: InsertExploreSnapshot :exec
+INSERT INTO explore_posts (computed_at, post_id, author_id, score)
+SELECT $1, e.post_id, e.author_id, e.score
+FROM unnest($2::int[], $3::int[], $4::float8[]) AS e(post_id, author_id, score)
+`
+
+type InsertExploreSnapshotParams struct {
+	ComputedAt pgtype.Timestamptz `json:"computed_at"`
+	PostIds    []int32            `json:"post_ids"`
+	AuthorIds  []int32            `json:"author_ids"`
+	Scores     []float64          `json:"scores"`
+}
+
+func (q *Queries) InsertExploreSnapshot(ctx context.Context, arg InsertExploreSnapshotParams) error {
+	_, err := q.db.Exec(ctx, insertExploreSnapshot,
+		arg.ComputedAt,
+		arg.PostIds,
+		arg.AuthorIds,
+		arg.Scores,
+	)
+	return err
+}
+
+const latestExploreSnapshotAt = `-- name: LatestExploreSnapshotAt :one
+SELECT MAX(computed_at)::timestamptz AS computed_at FROM explore_posts
+`
+
+func (q *Queries) LatestExploreSnapshotAt(ctx context.Context) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, latestExploreSnapshotAt)
+	var computedAt pgtype.Timestamptz
+	err := row.Scan(&computedAt)
+	return computedAt, err
+}
+
+const listExploreCandidates = `-- name: ListExploreCandidates :many
+SELECT
+    p.id AS post_id,
+    p.user_id AS author_id,
+    COALESCE(p.publish_at, p.created_at)::timestamptz AS published_at,
+    l.reactions_count,
+    c.comments_count,
+    r.reposts_count
+FROM posts p
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS reactions_count FROM likes WHERE post_id = p.id
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS comments_count FROM comments
+    WHERE post_id = p.id AND deleted_at IS NULL AND hidden_at IS NULL
+) c
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS reposts_count FROM reposts WHERE post_id = p.id
+) r
+WHERE p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND p.visibility = 'public'
+  AND COALESCE(p.publish_at, p.created_at) > $1
+  AND l.reactions_count + c.comments_count + r.reposts_count > 0
+ORDER BY published_at DESC
+LIMIT $2
+`
+
+type ListExploreCandidatesParams struct {
+	Since pgtype.Timestamptz `json:"since"`
+	Limit int32              `json:"limit"`
+}
+
+type ListExploreCandidatesRow struct {
+	PostID         int32              `json:"post_id"`
+	AuthorID       int32              `json:"author_id"`
+	PublishedAt    pgtype.Timestamptz `json:"published_at"`
+	ReactionsCount int64              `json:"reactions_count"`
+	CommentsCount  int64              `json:"comments_count"`
+	RepostsCount   int64              `json:"reposts_count"`
+}
+
+func (q *Queries) ListExploreCandidates(ctx context.Context, arg ListExploreCandidatesParams) ([]ListExploreCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listExploreCandidates, arg.Since, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExploreCandidatesRow
+	for rows.Next() {
+		var i ListExploreCandidatesRow
+		if err := rows.Scan(
+			&i.PostID,
+			&i.AuthorID,
+			&i.PublishedAt,
+			&i.ReactionsCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExplorePosts = `-- name: ListExplorePosts :many
+WITH snapshot AS (
+    SELECT COALESCE($1::timestamptz, MAX(computed_at)) AS computed_at
+    FROM explore_posts
+)
+SELECT
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive,
+    e.score,
+    e.computed_at,
+    l.likes_count,
+    c.comments_count
+FROM snapshot s
+JOIN explore_posts e ON e.computed_at = s.computed_at
+JOIN posts p ON p.id = e.post_id
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS likes_count FROM likes WHERE post_id = p.id AND reaction = 'like'
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS comments_count FROM comments
+    WHERE post_id = p.id AND deleted_at IS NULL AND hidden_at IS NULL
+) c
+WHERE p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND p.visibility = 'public'
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = $2 AND b.blocked_id = p.user_id)
+       OR (b.blocker_id = p.user_id AND b.blocked_id = $2)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes m WHERE m.muter_id = $2 AND m.muted_id = p.user_id
+  )
+  AND ($3::float8 IS NULL
+    OR (NOT $4::bool AND (e.score, p.id) < ($3::float8, $5::int))
+    OR ($4::bool AND (e.score, p.id) > ($3::float8, $5::int)))
+ORDER BY
+    CASE WHEN $4::bool THEN e.score END,
+    CASE WHEN $4::bool THEN p.id END,
+    e.score DESC,
+    p.id DESC
+LIMIT $6 OFFSET $7
+`
+
+type ListExplorePostsParams struct {
+	CursorTime  pgtype.Timestamptz `json:"cursor_time"`
+	ViewerID    int32              `json:"viewer_id"`
+	CursorScore pgtype.Float8      `json:"cursor_score"`
+	Backward    bool               `json:"backward"`
+	CursorID    int32              `json:"cursor_id"`
+	Limit       int32              `json:"limit"`
+	Offset      int32              `json:"offset"`
+}
+
+type ListExplorePostsRow struct {
+	Post          Post               `json:"post"`
+	Score         float64            `json:"score"`
+	ComputedAt    pgtype.Timestamptz `json:"computed_at"`
+	LikesCount    int64              `json:"likes_count"`
+	CommentsCount int64              `json:"comments_count"`
+}
+
+func (q *Queries) ListExplorePosts(ctx context.Context, arg ListExplorePostsParams) ([]ListExplorePostsRow, error) {
+	rows, err := q.db.Query(ctx, listExplorePosts,
+		arg.CursorTime,
+		arg.ViewerID,
+		arg.CursorScore,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExplorePostsRow
+	for rows.Next() {
+		var i ListExplorePostsRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Content,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.Status,
+			&i.Post.PublishAt,
+			&i.Post.Edited,
+			&i.Post.DeletedAt,
+			&i.Post.QuotePostID,
+			&i.Post.Visibility,
+			&i.Post.ContentHtml,
+			&i.Post.ContentWarning,
+			&i.Post.Sensitive,
+			&i.Post.Language,
+			&i.Post.CommentsLocked,
+			&i.Post.CommentPolicy,
+			&i.Post.ModeratorContentWarning,
+			&i.Post.ModeratorSensitive,
 			&i.Score,
+			&i.ComputedAt,
+			&i.LikesCount,
+			&i.CommentsCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeedRankingWeights = `-- name: ListFeedRankingWeights :many
+SELECT ranking, likes, comments, age, gravity, updated_at FROM feed_ranking_weights ORDER BY ranking
+`
+
+func (q *Queries) ListFeedRankingWeights(ctx context.Context) ([]FeedRankingWeight, error) {
+	rows, err := q.db.Query(ctx, listFeedRankingWeights)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedRankingWeight
+	for rows.Next() {
+		var i FeedRankingWeight
+		if err := rows.Scan(
+			&i.Ranking,
+			&i.Likes,
+			&i.Comments,
+			&i.Age,
+			&i.Gravity,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -75,3 +436,49 @@ func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow,
 	}
 	return items, nil
 }
+
+const tryLockExploreSnapshot = `-- name: TryLockExploreSnapshot :one
+SELECT pg_try_advisory_xact_lock(hashtext('explore_posts'))
+`
+
+func (q *Queries) TryLockExploreSnapshot(ctx context.Context) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockExploreSnapshot)
+	var pgTryAdvisoryXactLock bool
+	err := row.Scan(&pgTryAdvisoryXactLock)
+	return pgTryAdvisoryXactLock, err
+}
+
+const updateFeedRankingWeights = `-- name: UpdateFeedRankingWeights :one
+UPDATE feed_ranking_weights
+SET likes = $2, comments = $3, age = $4, gravity = $5, updated_at = NOW()
+WHERE ranking = $1
+RETURNING ranking, likes, comments, age, gravity, updated_at
+`
+
+type UpdateFeedRankingWeightsParams struct {
+	Ranking  string  `json:"ranking"`
+	Likes    float64 `json:"likes"`
+	Comments float64 `json:"comments"`
+	Age      float64 `json:"age"`
+	Gravity  float64 `json:"gravity"`
+}
+
+func (q *Queries) UpdateFeedRankingWeights(ctx context.Context, arg UpdateFeedRankingWeightsParams) (FeedRankingWeight, error) {
+	row := q.db.QueryRow(ctx, updateFeedRankingWeights,
+		arg.Ranking,
+		arg.Likes,
+		arg.Comments,
+		arg.Age,
+		arg.Gravity,
+	)
+	var i FeedRankingWeight
+	err := row.Scan(
+		&i.Ranking,
+		&i.Likes,
+		&i.Comments,
+		&i.Age,
+		&i.Gravity,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -7,6 +7,8 @@ package repo
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countFollowers = `-- name: CountFollowers :one
@@ -32,16 +34,41 @@ func (q *Queries) CountFollowing(ctx context.Context, followerID int32) (int64,
 }
 
 const followUser = `-- name: FollowUser :one
-INSERT INTO follows (follower_id, following_id) VALUES ($1, $2) RETURNING id, follower_id, following_id, created_at
+WITH followed AS (
+    INSERT INTO follows (follower_id, following_id)
+    VALUES ($1, $2)
+    RETURNING id, follower_id, following_id, created_at
+), backfilled AS (
+    INSERT INTO timelines (user_id, post_id, author_id, created_at)
+    SELECT f.follower_id, p.id, p.user_id, COALESCE(p.publish_at, p.created_at)
+    FROM followed f
+    JOIN posts p ON p.user_id = f.following_id
+    JOIN users u ON u.id = p.user_id
+    WHERE NOT u.fanout_on_read
+      AND p.status = 'published'
+      AND p.deleted_at IS NULL
+      AND COALESCE(p.publish_at, p.created_at) > $3
+    ORDER BY COALESCE(p.publish_at, p.created_at) DESC
+    LIMIT $4
+    ON CONFLICT (user_id, post_id) DO NOTHING
+)
+SELECT id, follower_id, following_id, created_at FROM followed
 `
 
 type FollowUserParams struct {
-	FollowerID  int32 `json:"follower_id"`
-	FollowingID int32 `json:"following_id"`
+	FollowerID  int32              `json:"follower_id"`
+	FollowingID int32              `json:"following_id"`
+	Since       pgtype.Timestamptz `json:"since"`
+	Limit       int32              `json:"limit"`
 }
 
 func (q *Queries) FollowUser(ctx context.Context, arg FollowUserParams) (Follow, error) {
-	row := q.db.QueryRow(ctx, followUser, arg.FollowerID, arg.FollowingID)
+	row := q.db.QueryRow(ctx, followUser,
+		arg.FollowerID,
+		arg.FollowingID,
+		arg.Since,
+		arg.Limit,
+	)
 	var i Follow
 	err := row.Scan(
 		&i.ID,
@@ -53,17 +80,37 @@ func (q *Queries) FollowUser(ctx context.Context, arg FollowUserParams) (Follow,
 }
 
 const listFollowers = `-- name: ListFollowers :many
-SELECT id, follower_id, following_id, created_at FROM follows WHERE following_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3
+SELECT id, follower_id, following_id, created_at FROM follows
+WHERE following_id = $1
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (created_at, id) < ($2::timestamptz, $4::int))
+    OR ($3::bool AND (created_at, id) > ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN created_at END,
+    CASE WHEN $3::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT $5 OFFSET $6
 `
 
 type ListFollowersParams struct {
-	FollowingID int32 `json:"following_id"`
-	Limit       int32 `json:"limit"`
-	Offset      int32 `json:"offset"`
+	FollowingID int32              `json:"following_id"`
+	CursorTime  pgtype.Timestamptz `json:"cursor_time"`
+	Backward    bool               `json:"backward"`
+	CursorID    int32              `json:"cursor_id"`
+	Limit       int32              `json:"limit"`
+	Offset      int32              `json:"offset"`
 }
 
 func (q *Queries) ListFollowers(ctx context.Context, arg ListFollowersParams) ([]Follow, error) {
-	rows, err := q.db.Query(ctx, listFollowers, arg.FollowingID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listFollowers,
+		arg.FollowingID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -88,17 +135,37 @@ func (q *Queries) ListFollowers(ctx context.Context, arg ListFollowersParams) ([
 }
 
 const listFollowing = `-- name: ListFollowing :many
-SELECT id, follower_id, following_id, created_at FROM follows WHERE follower_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3
+SELECT id, follower_id, following_id, created_at FROM follows
+WHERE follower_id = $1
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (created_at, id) < ($2::timestamptz, $4::int))
+    OR ($3::bool AND (created_at, id) > ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN created_at END,
+    CASE WHEN $3::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT $5 OFFSET $6
 `
 
 type ListFollowingParams struct {
-	FollowerID int32 `json:"follower_id"`
-	Limit      int32 `json:"limit"`
-	Offset     int32 `json:"offset"`
+	FollowerID int32              `json:"follower_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
 }
 
 func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([]Follow, error) {
-	rows, err := q.db.Query(ctx, listFollowing, arg.FollowerID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listFollowing,
+		arg.FollowerID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +190,9 @@ func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([
 }
 
 const unfollowUser = `-- name: UnfollowUser :exec
+WITH pruned AS (
+    DELETE FROM timelines WHERE user_id = $1 AND author_id = $2
+)
 DELETE FROM follows WHERE follower_id = $1 AND following_id = $2
 `
 
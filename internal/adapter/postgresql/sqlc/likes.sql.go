@@ -7,10 +7,12 @@ package repo
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countLikesByPostID = `-- name: CountLikesByPostID :one
-SELECT COUNT(*) FROM likes WHERE post_id = $1
+SELECT COUNT(*) FROM likes WHERE post_id = $1 AND reaction = 'like'
 `
 
 func (q *Queries) CountLikesByPostID(ctx context.Context, postID int32) (int64, error) {
@@ -21,7 +23,9 @@ func (q *Queries) CountLikesByPostID(ctx context.Context, postID int32) (int64,
 }
 
 const likePost = `-- name: LikePost :one
-INSERT INTO likes (user_id, post_id) VALUES ($1, $2) RETURNING id, user_id, post_id, created_at
+INSERT INTO likes (user_id, post_id) VALUES ($1, $2)
+ON CONFLICT (user_id, post_id) DO UPDATE SET reaction = 'like' WHERE likes.reaction <> 'like'
+RETURNING id, user_id, post_id, created_at, reaction
 `
 
 type LikePostParams struct {
@@ -37,22 +41,128 @@ func (q *Queries) LikePost(ctx context.Context, arg LikePostParams) (Like, error
 		&i.UserID,
 		&i.PostID,
 		&i.CreatedAt,
+		&i.Reaction,
 	)
 	return i, err
 }
 
+const listLikedPostsByUserID = `-- name: ListLikedPostsByUserID :many
+SELECT
+    l.id AS like_id,
+    l.created_at AS liked_at,
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive
+FROM likes l
+JOIN posts p ON p.id = l.post_id
+WHERE l.user_id = $1 AND l.reaction = 'like'
+  AND p.status = 'published' AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, $2)
+  AND ($3::int = 0
+    OR (NOT $4::bool AND l.id < $3::int)
+    OR ($4::bool AND l.id > $3::int))
+ORDER BY
+    CASE WHEN $4::bool THEN l.id END,
+    l.id DESC
+LIMIT $5 OFFSET $6
+`
+
+type ListLikedPostsByUserIDParams struct {
+	UserID   int32 `json:"user_id"`
+	ViewerID int32 `json:"viewer_id"`
+	CursorID int32 `json:"cursor_id"`
+	Backward bool  `json:"backward"`
+	Limit    int32 `json:"limit"`
+	Offset   int32 `json:"offset"`
+}
+
+type ListLikedPostsByUserIDRow struct {
+	LikeID  int32              `json:"like_id"`
+	LikedAt pgtype.Timestamptz `json:"liked_at"`
+	Post    Post               `json:"post"`
+}
+
+func (q *Queries) ListLikedPostsByUserID(ctx context.Context, arg ListLikedPostsByUserIDParams) ([]ListLikedPostsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listLikedPostsByUserID,
+		arg.UserID,
+		arg.ViewerID,
+		arg.CursorID,
+		arg.Backward,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLikedPostsByUserIDRow
+	for rows.Next() {
+		var i ListLikedPostsByUserIDRow
+		if err := rows.Scan(
+			&i.LikeID,
+			&i.LikedAt,
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Content,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.Status,
+			&i.Post.PublishAt,
+			&i.Post.Edited,
+			&i.Post.DeletedAt,
+			&i.Post.QuotePostID,
+			&i.Post.Visibility,
+			&i.Post.ContentHtml,
+			&i.Post.ContentWarning,
+			&i.Post.Sensitive,
+			&i.Post.Language,
+			&i.Post.CommentsLocked,
+			&i.Post.CommentPolicy,
+			&i.Post.ModeratorContentWarning,
+			&i.Post.ModeratorSensitive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLikesByPostID = `-- name: ListLikesByPostID :many
-SELECT id, user_id, post_id, created_at FROM likes WHERE post_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3
+SELECT l.id, l.user_id, l.post_id, l.created_at, l.reaction FROM likes l
+JOIN posts p ON p.id = l.post_id
+WHERE l.post_id = $1 AND l.reaction = 'like' AND p.deleted_at IS NULL
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (l.created_at, l.id) < ($2::timestamptz, $4::int))
+    OR ($3::bool AND (l.created_at, l.id) > ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN l.created_at END,
+    CASE WHEN $3::bool THEN l.id END,
+    l.created_at DESC,
+    l.id DESC
+LIMIT $5 OFFSET $6
 `
 
 type ListLikesByPostIDParams struct {
-	PostID int32 `json:"post_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	PostID     int32              `json:"post_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
 }
 
 func (q *Queries) ListLikesByPostID(ctx context.Context, arg ListLikesByPostIDParams) ([]Like, error) {
-	rows, err := q.db.Query(ctx, listLikesByPostID, arg.PostID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listLikesByPostID,
+		arg.PostID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +175,7 @@ func (q *Queries) ListLikesByPostID(ctx context.Context, arg ListLikesByPostIDPa
 			&i.UserID,
 			&i.PostID,
 			&i.CreatedAt,
+			&i.Reaction,
 		); err != nil {
 			return nil, err
 		}
@@ -77,7 +188,7 @@ func (q *Queries) ListLikesByPostID(ctx context.Context, arg ListLikesByPostIDPa
 }
 
 const unlikePost = `-- name: UnlikePost :exec
-DELETE FROM likes WHERE user_id = $1 AND post_id = $2
+DELETE FROM likes WHERE user_id = $1 AND post_id = $2 AND reaction = 'like'
 `
 
 type UnlikePostParams struct {
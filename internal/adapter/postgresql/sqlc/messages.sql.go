@@ -49,18 +49,35 @@ const listMessagesByChatID = `-- name: ListMessagesByChatID :many
 SELECT id, chat_id, sender_id, content, created_at, is_read
 FROM messages
 WHERE chat_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (created_at, id) < ($2::timestamptz, $4::int))
+    OR ($3::bool AND (created_at, id) > ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN created_at END,
+    CASE WHEN $3::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT $5 OFFSET $6
 `
 
 type ListMessagesByChatIDParams struct {
-	ChatID int32 `json:"chat_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	ChatID     int32              `json:"chat_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
 }
 
 func (q *Queries) ListMessagesByChatID(ctx context.Context, arg ListMessagesByChatIDParams) ([]Message, error) {
-	rows, err := q.db.Query(ctx, listMessagesByChatID, arg.ChatID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listMessagesByChatID,
+		arg.ChatID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,44 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Attachment struct {
+	ID         int32              `json:"id"`
+	UserID     int32              `json:"user_id"`
+	PostID     pgtype.Int4        `json:"post_id"`
+	Position   int32              `json:"position"`
+	Kind       string             `json:"kind"`
+	MimeType   string             `json:"mime_type"`
+	SizeBytes  int64              `json:"size_bytes"`
+	Width      pgtype.Int4        `json:"width"`
+	Height     pgtype.Int4        `json:"height"`
+	StorageKey string             `json:"storage_key"`
+	Blurhash   pgtype.Text        `json:"blurhash"`
+	AltText    string             `json:"alt_text"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Block struct {
+	BlockerID int32              `json:"blocker_id"`
+	BlockedID int32              `json:"blocked_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Bookmark struct {
+	ID           int32              `json:"id"`
+	UserID       int32              `json:"user_id"`
+	PostID       int32              `json:"post_id"`
+	CollectionID pgtype.Int4        `json:"collection_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type BookmarkCollection struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	Name      string             `json:"name"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Chat struct {
 	ID        int32              `json:"id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
@@ -20,11 +58,50 @@ type ChatParticipant struct {
 }
 
 type Comment struct {
+	ID          int32              `json:"id"`
+	PostID      int32              `json:"post_id"`
+	UserID      int32              `json:"user_id"`
+	Content     string             `json:"content"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Edited      bool               `json:"edited"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	ContentHtml string             `json:"-"`
+	Language    string             `json:"language"`
+	ParentID    pgtype.Int4        `json:"parent_id"`
+	Depth       int32              `json:"depth"`
+	HiddenAt    pgtype.Timestamptz `json:"hidden_at"`
+	DeletedBy   pgtype.Int4        `json:"deleted_by"`
+}
+
+type CommentLike struct {
 	ID        int32              `json:"id"`
-	PostID    int32              `json:"post_id"`
 	UserID    int32              `json:"user_id"`
+	CommentID int32              `json:"comment_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Reaction  string             `json:"reaction"`
+}
+
+type CommentRevision struct {
+	ID        int32              `json:"id"`
+	CommentID int32              `json:"comment_id"`
 	Content   string             `json:"content"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ExplorePost struct {
+	ComputedAt pgtype.Timestamptz `json:"computed_at"`
+	PostID     int32              `json:"post_id"`
+	AuthorID   int32              `json:"author_id"`
+	Score      float64            `json:"score"`
+}
+
+type FeedRankingWeight struct {
+	Ranking   string             `json:"ranking"`
+	Likes     float64            `json:"likes"`
+	Comments  float64            `json:"comments"`
+	Age       float64            `json:"age"`
+	Gravity   float64            `json:"gravity"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
@@ -35,11 +112,40 @@ type Follow struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type Hashtag struct {
+	ID        int32              `json:"id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Like struct {
 	ID        int32              `json:"id"`
 	UserID    int32              `json:"user_id"`
 	PostID    int32              `json:"post_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Reaction  string             `json:"reaction"`
+}
+
+type LinkPreview struct {
+	Url         string             `json:"url"`
+	Status      string             `json:"status"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	ImageUrl    string             `json:"image_url"`
+	SiteName    string             `json:"site_name"`
+	ClaimedAt   pgtype.Timestamptz `json:"claimed_at"`
+	FetchedAt   pgtype.Timestamptz `json:"fetched_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type Mention struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	AuthorID  int32              `json:"author_id"`
+	PostID    pgtype.Int4        `json:"post_id"`
+	CommentID pgtype.Int4        `json:"comment_id"`
+	MessageID pgtype.Int4        `json:"message_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Message struct {
@@ -51,20 +157,136 @@ type Message struct {
 	IsRead    bool               `json:"is_read"`
 }
 
-type Post struct {
+type Mute struct {
+	MuterID   int32              `json:"muter_id"`
+	MutedID   int32              `json:"muted_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Notification struct {
 	ID        int32              `json:"id"`
 	UserID    int32              `json:"user_id"`
+	Type      string             `json:"type"`
+	PostID    pgtype.Int4        `json:"post_id"`
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type PinnedPost struct {
+	UserID    int32              `json:"user_id"`
+	PostID    int32              `json:"post_id"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Poll struct {
+	ID        int32              `json:"id"`
+	PostID    int32              `json:"post_id"`
+	Multiple  bool               `json:"multiple"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	ClosedAt  pgtype.Timestamptz `json:"closed_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type PollOption struct {
+	ID       int32  `json:"id"`
+	PollID   int32  `json:"poll_id"`
+	Position int32  `json:"position"`
+	Text     string `json:"text"`
+}
+
+type PollVote struct {
+	PollID    int32              `json:"poll_id"`
+	OptionID  int32              `json:"option_id"`
+	UserID    int32              `json:"user_id"`
+	Multiple  bool               `json:"multiple"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Post struct {
+	ID                      int32              `json:"id"`
+	UserID                  int32              `json:"user_id"`
+	Title                   string             `json:"title"`
+	Content                 string             `json:"content"`
+	CreatedAt               pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt               pgtype.Timestamptz `json:"updated_at"`
+	Status                  string             `json:"status"`
+	PublishAt               pgtype.Timestamptz `json:"publish_at"`
+	Edited                  bool               `json:"edited"`
+	DeletedAt               pgtype.Timestamptz `json:"deleted_at"`
+	QuotePostID             pgtype.Int4        `json:"quote_post_id"`
+	Visibility              string             `json:"visibility"`
+	ContentHtml             string             `json:"-"`
+	ContentWarning          string             `json:"content_warning"`
+	Sensitive               bool               `json:"sensitive"`
+	Language                string             `json:"language"`
+	CommentsLocked          bool               `json:"comments_locked"`
+	CommentPolicy           string             `json:"comment_policy"`
+	ModeratorContentWarning string             `json:"moderator_content_warning"`
+	ModeratorSensitive      bool               `json:"moderator_sensitive"`
+}
+
+type PostHashtag struct {
+	PostID    int32 `json:"post_id"`
+	HashtagID int32 `json:"hashtag_id"`
+}
+
+type PostImpression struct {
+	PostID      int32              `json:"post_id"`
+	ViewerID    int32              `json:"viewer_id"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+}
+
+type PostLink struct {
+	PostID int32  `json:"post_id"`
+	Url    string `json:"url"`
+}
+
+type PostRevision struct {
+	ID        int32              `json:"id"`
+	PostID    int32              `json:"post_id"`
 	Title     string             `json:"title"`
 	Content   string             `json:"content"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
-type User struct {
+type PostViewCount struct {
+	PostID     int32 `json:"post_id"`
+	ViewsCount int64 `json:"views_count"`
+}
+
+type Repost struct {
 	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	Email     string             `json:"email"`
-	Password  string             `json:"password"`
+	UserID    int32              `json:"user_id"`
+	PostID    int32              `json:"post_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Timeline struct {
+	UserID    int32              `json:"user_id"`
+	PostID    int32              `json:"post_id"`
+	AuthorID  int32              `json:"author_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type TimelineFanout struct {
+	PostID    int32              `json:"post_id"`
+	ClaimedAt pgtype.Timestamptz `json:"claimed_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type User struct {
+	ID               int32              `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Password         string             `json:"password"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	Role             string             `json:"role"`
+	Handle           string             `json:"handle"`
+	MentionPolicy    string             `json:"mention_policy"`
+	SensitiveContent string             `json:"sensitive_content"`
+	HideLikes        bool               `json:"hide_likes"`
+	FanoutOnRead     bool               `json:"fanout_on_read"`
+	FeedRanking      string             `json:"feed_ranking"`
 }
@@ -11,8 +11,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const canCommentOnPost = `-- name: CanCommentOnPost :one
+SELECT
+    p.user_id = $1
+    OR p.comment_policy = 'everyone'
+    OR (p.comment_policy = 'followers' AND EXISTS (
+        SELECT 1 FROM follows f WHERE f.follower_id = $1 AND f.following_id = p.user_id
+    ))
+    OR (p.comment_policy = 'mentioned' AND EXISTS (
+        SELECT 1 FROM mentions m WHERE m.post_id = p.id AND m.user_id = $1
+    )) AS allowed
+FROM posts p
+WHERE p.id = $2
+`
+
+type CanCommentOnPostParams struct {
+	UserID int32 `json:"user_id"`
+	ID     int32 `json:"id"`
+}
+
+func (q *Queries) CanCommentOnPost(ctx context.Context, arg CanCommentOnPostParams) (bool, error) {
+	row := q.db.QueryRow(ctx, canCommentOnPost, arg.UserID, arg.ID)
+	var allowed bool
+	err := row.Scan(&allowed)
+	return allowed, err
+}
+
 const countPostsByUserID = `-- name: CountPostsByUserID :one
-SELECT COUNT(*) FROM posts WHERE user_id = $1
+SELECT COUNT(*) FROM posts WHERE user_id = $1 AND status = 'published' AND deleted_at IS NULL
 `
 
 func (q *Queries) CountPostsByUserID(ctx context.Context, userID int32) (int64, error) {
@@ -23,17 +49,35 @@ func (q *Queries) CountPostsByUserID(ctx context.Context, userID int32) (int64,
 }
 
 const createPost = `-- name: CreatePost :one
-INSERT INTO posts (user_id, title, content) VALUES ($1, $2, $3) RETURNING id, user_id, title, content, created_at, updated_at
+INSERT INTO posts (user_id, title, content, status, publish_at, quote_post_id, visibility, content_warning, sensitive, language) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
 `
 
 type CreatePostParams struct {
-	UserID  int32  `json:"user_id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	UserID         int32              `json:"user_id"`
+	Title          string             `json:"title"`
+	Content        string             `json:"content"`
+	Status         string             `json:"status"`
+	PublishAt      pgtype.Timestamptz `json:"publish_at"`
+	QuotePostID    pgtype.Int4        `json:"quote_post_id"`
+	Visibility     string             `json:"visibility"`
+	ContentWarning string             `json:"content_warning"`
+	Sensitive      bool               `json:"sensitive"`
+	Language       string             `json:"language"`
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
-	row := q.db.QueryRow(ctx, createPost, arg.UserID, arg.Title, arg.Content)
+	row := q.db.QueryRow(ctx, createPost,
+		arg.UserID,
+		arg.Title,
+		arg.Content,
+		arg.Status,
+		arg.PublishAt,
+		arg.QuotePostID,
+		arg.Visibility,
+		arg.ContentWarning,
+		arg.Sensitive,
+		arg.Language,
+	)
 	var i Post
 	err := row.Scan(
 		&i.ID,
@@ -42,12 +86,29 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
 	)
 	return i, err
 }
 
 const deletePost = `-- name: DeletePost :exec
-DELETE FROM posts WHERE id = $1
+WITH unpinned AS (
+    DELETE FROM pinned_posts WHERE post_id = $1
+)
+UPDATE posts SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeletePost(ctx context.Context, id int32) error {
@@ -56,7 +117,7 @@ func (q *Queries) DeletePost(ctx context.Context, id int32) error {
 }
 
 const findPostByID = `-- name: FindPostByID :one
-SELECT id, user_id, title, content, created_at, updated_at FROM posts WHERE id = $1
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) FindPostByID(ctx context.Context, id int32) (Post, error) {
@@ -69,22 +130,334 @@ func (q *Queries) FindPostByID(ctx context.Context, id int32) (Post, error) {
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
 	)
 	return i, err
 }
 
+const findPostByIDIncludingDeleted = `-- name: FindPostByIDIncludingDeleted :one
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts WHERE id = $1
+`
+
+func (q *Queries) FindPostByIDIncludingDeleted(ctx context.Context, id int32) (Post, error) {
+	row := q.db.QueryRow(ctx, findPostByIDIncludingDeleted, id)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
+	)
+	return i, err
+}
+
+const findVisiblePostByID = `-- name: FindVisiblePostByID :one
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts
+WHERE id = $1
+  AND deleted_at IS NULL
+  AND can_view_post(id, user_id, visibility, $2)
+`
+
+type FindVisiblePostByIDParams struct {
+	ID       int32 `json:"id"`
+	ViewerID int32 `json:"viewer_id"`
+}
+
+func (q *Queries) FindVisiblePostByID(ctx context.Context, arg FindVisiblePostByIDParams) (Post, error) {
+	row := q.db.QueryRow(ctx, findVisiblePostByID, arg.ID, arg.ViewerID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
+	)
+	return i, err
+}
+
+const listDraftsByUserID = `-- name: ListDraftsByUserID :many
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts
+WHERE user_id = $1 AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (updated_at, id) < ($2::timestamptz, $4::int))
+    OR ($3::bool AND (updated_at, id) > ($2::timestamptz, $4::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN updated_at END,
+    CASE WHEN $3::bool THEN id END,
+    updated_at DESC,
+    id DESC
+LIMIT $5 OFFSET $6
+`
+
+type ListDraftsByUserIDParams struct {
+	UserID     int32              `json:"user_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
+}
+
+func (q *Queries) ListDraftsByUserID(ctx context.Context, arg ListDraftsByUserIDParams) ([]Post, error) {
+	rows, err := q.db.Query(ctx, listDraftsByUserID,
+		arg.UserID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.QuotePostID,
+			&i.Visibility,
+			&i.ContentHtml,
+			&i.ContentWarning,
+			&i.Sensitive,
+			&i.Language,
+			&i.CommentsLocked,
+			&i.CommentPolicy,
+			&i.ModeratorContentWarning,
+			&i.ModeratorSensitive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPostsByIDsIncludingDeleted = `-- name: ListPostsByIDsIncludingDeleted :many
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) ListPostsByIDsIncludingDeleted(ctx context.Context, ids []int32) ([]Post, error) {
+	rows, err := q.db.Query(ctx, listPostsByIDsIncludingDeleted, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.QuotePostID,
+			&i.Visibility,
+			&i.ContentHtml,
+			&i.ContentWarning,
+			&i.Sensitive,
+			&i.Language,
+			&i.CommentsLocked,
+			&i.CommentPolicy,
+			&i.ModeratorContentWarning,
+			&i.ModeratorSensitive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPostsByUserID = `-- name: ListPostsByUserID :many
-SELECT id, user_id, title, content, created_at, updated_at FROM posts WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3
+SELECT p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive, k.pin_rank FROM posts p
+LEFT JOIN pinned_posts pp ON pp.post_id = p.id
+CROSS JOIN LATERAL (SELECT COALESCE(pp.position, 2147483647)::float8 AS pin_rank) k
+WHERE p.user_id = $1
+  AND p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, $2)
+  AND ($3::float8 IS NULL
+    OR (NOT $4::bool AND (k.pin_rank > $3::float8 OR (k.pin_rank = $3::float8 AND (p.created_at, p.id) < ($5::timestamptz, $6::int))))
+    OR ($4::bool AND (k.pin_rank < $3::float8 OR (k.pin_rank = $3::float8 AND (p.created_at, p.id) > ($5::timestamptz, $6::int)))))
+ORDER BY
+    CASE WHEN $4::bool THEN k.pin_rank END DESC,
+    CASE WHEN $4::bool THEN p.created_at END,
+    CASE WHEN $4::bool THEN p.id END,
+    k.pin_rank,
+    p.created_at DESC,
+    p.id DESC
+LIMIT $7 OFFSET $8
 `
 
 type ListPostsByUserIDParams struct {
-	UserID int32 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID      int32              `json:"user_id"`
+	ViewerID    int32              `json:"viewer_id"`
+	CursorScore pgtype.Float8      `json:"cursor_score"`
+	Backward    bool               `json:"backward"`
+	CursorTime  pgtype.Timestamptz `json:"cursor_time"`
+	CursorID    int32              `json:"cursor_id"`
+	Limit       int32              `json:"limit"`
+	Offset      int32              `json:"offset"`
 }
 
-func (q *Queries) ListPostsByUserID(ctx context.Context, arg ListPostsByUserIDParams) ([]Post, error) {
-	rows, err := q.db.Query(ctx, listPostsByUserID, arg.UserID, arg.Limit, arg.Offset)
+type ListPostsByUserIDRow struct {
+	Post    Post    `json:"post"`
+	PinRank float64 `json:"pin_rank"`
+}
+
+func (q *Queries) ListPostsByUserID(ctx context.Context, arg ListPostsByUserIDParams) ([]ListPostsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listPostsByUserID,
+		arg.UserID,
+		arg.ViewerID,
+		arg.CursorScore,
+		arg.Backward,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPostsByUserIDRow
+	for rows.Next() {
+		var i ListPostsByUserIDRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Content,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.Status,
+			&i.Post.PublishAt,
+			&i.Post.Edited,
+			&i.Post.DeletedAt,
+			&i.Post.QuotePostID,
+			&i.Post.Visibility,
+			&i.Post.ContentHtml,
+			&i.Post.ContentWarning,
+			&i.Post.Sensitive,
+			&i.Post.Language,
+			&i.Post.CommentsLocked,
+			&i.Post.CommentPolicy,
+			&i.Post.ModeratorContentWarning,
+			&i.Post.ModeratorSensitive,
+			&i.PinRank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedPostsByUserID = `-- name: ListTrashedPostsByUserID :many
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+  AND ($2::timestamptz IS NULL
+    OR (NOT $3::bool AND (deleted_at, 'post'::text, id) < ($2::timestamptz, $4::text, $5::int))
+    OR ($3::bool AND (deleted_at, 'post'::text, id) > ($2::timestamptz, $4::text, $5::int)))
+ORDER BY
+    CASE WHEN $3::bool THEN deleted_at END,
+    CASE WHEN $3::bool THEN id END,
+    deleted_at DESC,
+    id DESC
+LIMIT $6 OFFSET $7
+`
+
+type ListTrashedPostsByUserIDParams struct {
+	UserID     int32              `json:"user_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	Backward   bool               `json:"backward"`
+	CursorType string             `json:"cursor_type"`
+	CursorID   int32              `json:"cursor_id"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
+}
+
+func (q *Queries) ListTrashedPostsByUserID(ctx context.Context, arg ListTrashedPostsByUserIDParams) ([]Post, error) {
+	rows, err := q.db.Query(ctx, listTrashedPostsByUserID,
+		arg.UserID,
+		arg.CursorTime,
+		arg.Backward,
+		arg.CursorType,
+		arg.CursorID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +472,20 @@ func (q *Queries) ListPostsByUserID(ctx context.Context, arg ListPostsByUserIDPa
 			&i.Content,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.QuotePostID,
+			&i.Visibility,
+			&i.ContentHtml,
+			&i.ContentWarning,
+			&i.Sensitive,
+			&i.Language,
+			&i.CommentsLocked,
+			&i.CommentPolicy,
+			&i.ModeratorContentWarning,
+			&i.ModeratorSensitive,
 		); err != nil {
 			return nil, err
 		}
@@ -110,24 +497,261 @@ func (q *Queries) ListPostsByUserID(ctx context.Context, arg ListPostsByUserIDPa
 	return items, nil
 }
 
+const publishDuePosts = `-- name: PublishDuePosts :many
+WITH published AS (
+    UPDATE posts
+    SET status = 'published', created_at = publish_at, updated_at = NOW()
+    WHERE id IN (
+        SELECT id FROM posts
+        WHERE status = 'scheduled' AND publish_at <= NOW() AND deleted_at IS NULL
+        ORDER BY publish_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
+), queued AS (
+    INSERT INTO timeline_fanout (post_id)
+    SELECT id FROM published
+    ON CONFLICT (post_id) DO NOTHING
+)
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM published
+`
+
+func (q *Queries) PublishDuePosts(ctx context.Context, limit int32) ([]Post, error) {
+	rows, err := q.db.Query(ctx, publishDuePosts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.Edited,
+			&i.DeletedAt,
+			&i.QuotePostID,
+			&i.Visibility,
+			&i.ContentHtml,
+			&i.ContentWarning,
+			&i.Sensitive,
+			&i.Language,
+			&i.CommentsLocked,
+			&i.CommentPolicy,
+			&i.ModeratorContentWarning,
+			&i.ModeratorSensitive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedPosts = `-- name: PurgeDeletedPosts :execrows
+DELETE FROM posts WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedPosts(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedPosts, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restorePost = `-- name: RestorePost :one
+UPDATE posts SET deleted_at = NULL WHERE id = $1 RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
+`
+
+func (q *Queries) RestorePost(ctx context.Context, id int32) (Post, error) {
+	row := q.db.QueryRow(ctx, restorePost, id)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
+	)
+	return i, err
+}
+
+const setPostCommentSettings = `-- name: SetPostCommentSettings :one
+UPDATE posts
+SET
+    comments_locked = COALESCE($1, comments_locked),
+    comment_policy = COALESCE($2, comment_policy)
+WHERE id = $3 AND deleted_at IS NULL
+RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
+`
+
+type SetPostCommentSettingsParams struct {
+	CommentsLocked pgtype.Bool `json:"comments_locked"`
+	CommentPolicy  pgtype.Text `json:"comment_policy"`
+	ID             int32       `json:"id"`
+}
+
+func (q *Queries) SetPostCommentSettings(ctx context.Context, arg SetPostCommentSettingsParams) (Post, error) {
+	row := q.db.QueryRow(ctx, setPostCommentSettings, arg.CommentsLocked, arg.CommentPolicy, arg.ID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
+	)
+	return i, err
+}
+
+const setPostContentHTML = `-- name: SetPostContentHTML :exec
+UPDATE posts SET content_html = $2 WHERE id = $1
+`
+
+type SetPostContentHTMLParams struct {
+	ID          int32  `json:"id"`
+	ContentHtml string `json:"content_html"`
+}
+
+func (q *Queries) SetPostContentHTML(ctx context.Context, arg SetPostContentHTMLParams) error {
+	_, err := q.db.Exec(ctx, setPostContentHTML, arg.ID, arg.ContentHtml)
+	return err
+}
+
+const setPostFlags = `-- name: SetPostFlags :one
+UPDATE posts
+SET
+    moderator_content_warning = COALESCE($1, moderator_content_warning),
+    moderator_sensitive = COALESCE($2, moderator_sensitive)
+WHERE id = $3 AND deleted_at IS NULL
+RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
+`
+
+type SetPostFlagsParams struct {
+	ModeratorContentWarning pgtype.Text `json:"moderator_content_warning"`
+	ModeratorSensitive      pgtype.Bool `json:"moderator_sensitive"`
+	ID                      int32       `json:"id"`
+}
+
+func (q *Queries) SetPostFlags(ctx context.Context, arg SetPostFlagsParams) (Post, error) {
+	row := q.db.QueryRow(ctx, setPostFlags, arg.ModeratorContentWarning, arg.ModeratorSensitive, arg.ID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
+	)
+	return i, err
+}
+
 const updatePost = `-- name: UpdatePost :one
+WITH revision AS (
+    INSERT INTO post_revisions (post_id, title, content, created_at)
+    SELECT p.id, p.title, p.content, p.updated_at
+    FROM posts p
+    WHERE p.id = $1
+      AND p.status = 'published'
+      AND (p.title <> COALESCE($2, p.title) OR p.content <> COALESCE($3, p.content))
+), unpinned AS (
+    DELETE FROM pinned_posts
+    WHERE post_id = $1 AND COALESCE($4, 'public') <> 'public'
+)
 UPDATE posts 
 SET 
-    title = COALESCE($1, title),
-    content = COALESCE($2, content),
+    title = COALESCE($2, title),
+    content = COALESCE($3, content),
+    status = COALESCE($5, status),
+    publish_at = COALESCE($6, publish_at),
+    visibility = COALESCE($4, visibility),
+    content_warning = COALESCE($7, content_warning),
+    sensitive = COALESCE($8, sensitive),
+    edited = edited OR (status = 'published' AND (title <> COALESCE($2, title) OR content <> COALESCE($3, content))),
+    created_at = CASE WHEN status <> 'published' AND $5 = 'published' THEN NOW() ELSE created_at END,
     updated_at = NOW()
-WHERE id = $3
-RETURNING id, user_id, title, content, created_at, updated_at
+WHERE id = $1
+RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
 `
 
 type UpdatePostParams struct {
-	Title   pgtype.Text `json:"title"`
-	Content pgtype.Text `json:"content"`
-	ID      int32       `json:"id"`
+	ID             int32              `json:"id"`
+	Title          pgtype.Text        `json:"title"`
+	Content        pgtype.Text        `json:"content"`
+	Visibility     pgtype.Text        `json:"visibility"`
+	Status         pgtype.Text        `json:"status"`
+	PublishAt      pgtype.Timestamptz `json:"publish_at"`
+	ContentWarning pgtype.Text        `json:"content_warning"`
+	Sensitive      pgtype.Bool        `json:"sensitive"`
 }
 
 func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error) {
-	row := q.db.QueryRow(ctx, updatePost, arg.Title, arg.Content, arg.ID)
+	row := q.db.QueryRow(ctx, updatePost,
+		arg.ID,
+		arg.Title,
+		arg.Content,
+		arg.Visibility,
+		arg.Status,
+		arg.PublishAt,
+		arg.ContentWarning,
+		arg.Sensitive,
+	)
 	var i Post
 	err := row.Scan(
 		&i.ID,
@@ -136,6 +760,20 @@ func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, e
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.Edited,
+		&i.DeletedAt,
+		&i.QuotePostID,
+		&i.Visibility,
+		&i.ContentHtml,
+		&i.ContentWarning,
+		&i.Sensitive,
+		&i.Language,
+		&i.CommentsLocked,
+		&i.CommentPolicy,
+		&i.ModeratorContentWarning,
+		&i.ModeratorSensitive,
 	)
 	return i, err
 }
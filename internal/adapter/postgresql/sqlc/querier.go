@@ -11,43 +11,172 @@ import (
 )
 
 type Querier interface {
+	AttachToPost(ctx context.Context, arg AttachToPostParams) (int64, error)
+	BlockUser(ctx context.Context, arg BlockUserParams) (Block, error)
+	BookmarkPost(ctx context.Context, arg BookmarkPostParams) (Bookmark, error)
+	CanCommentOnPost(ctx context.Context, arg CanCommentOnPostParams) (bool, error)
+	ClaimPendingLinkPreviews(ctx context.Context, arg ClaimPendingLinkPreviewsParams) ([]string, error)
+	ClaimTimelineFanout(ctx context.Context, arg ClaimTimelineFanoutParams) ([]ClaimTimelineFanoutRow, error)
+	CloseExpiredPolls(ctx context.Context, limit int32) ([]int32, error)
+	CountBookmarkCollectionsByUserID(ctx context.Context, userID int32) (int64, error)
 	CountCommentsByPostID(ctx context.Context, postID int32) (int64, error)
 	CountFollowers(ctx context.Context, followingID int32) (int64, error)
 	CountFollowing(ctx context.Context, followerID int32) (int64, error)
+	CountLikesByCommentIDs(ctx context.Context, commentIds []int32) ([]CountLikesByCommentIDsRow, error)
 	CountLikesByPostID(ctx context.Context, postID int32) (int64, error)
+	CountPollVotersByPollIDs(ctx context.Context, pollIds []int32) ([]CountPollVotersByPollIDsRow, error)
 	CountPostsByUserID(ctx context.Context, userID int32) (int64, error)
+	CountReactionsByCommentIDs(ctx context.Context, commentIds []int32) ([]CountReactionsByCommentIDsRow, error)
+	CountReactionsByPostIDs(ctx context.Context, postIds []int32) ([]CountReactionsByPostIDsRow, error)
+	CountRepliesByCommentIDs(ctx context.Context, arg CountRepliesByCommentIDsParams) ([]CountRepliesByCommentIDsRow, error)
+	CountRepostsByPostIDs(ctx context.Context, postIds []int32) ([]CountRepostsByPostIDsRow, error)
+	CountUnattachedAttachments(ctx context.Context, arg CountUnattachedAttachmentsParams) (int64, error)
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error)
+	CreateBookmarkCollection(ctx context.Context, arg CreateBookmarkCollectionParams) (BookmarkCollection, error)
 	CreateChat(ctx context.Context, createdAt pgtype.Timestamptz) (Chat, error)
 	CreateChatParticipant(ctx context.Context, arg CreateChatParticipantParams) error
 	CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	CreateMessageMentions(ctx context.Context, arg CreateMessageMentionsParams) error
+	CreatePoll(ctx context.Context, arg CreatePollParams) (Poll, error)
+	CreatePollVotes(ctx context.Context, arg CreatePollVotesParams) (int64, error)
 	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	DeleteBookmark(ctx context.Context, arg DeleteBookmarkParams) (int64, error)
+	DeleteBookmarkCollection(ctx context.Context, id int32) error
 	DeleteChat(ctx context.Context, id int32) error
 	DeleteChatParticipant(ctx context.Context, arg DeleteChatParticipantParams) error
-	DeleteComment(ctx context.Context, id int32) error
+	DeleteComment(ctx context.Context, arg DeleteCommentParams) error
+	DeleteExpiredImpressions(ctx context.Context, windowStart pgtype.Timestamptz) (int64, error)
+	DeleteExpiredTimelineEntries(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteExploreSnapshotsBefore(ctx context.Context, computedAt pgtype.Timestamptz) error
+	DeleteOrphanedAttachments(ctx context.Context, arg DeleteOrphanedAttachmentsParams) ([]Attachment, error)
 	DeletePost(ctx context.Context, id int32) error
+	DeletePostLink(ctx context.Context, postID int32) error
+	DeleteTimelineFanout(ctx context.Context, postID int32) error
+	FanOutPost(ctx context.Context, postID int32) error
+	FindAttachmentByID(ctx context.Context, id int32) (Attachment, error)
+	FindBookmarkCollectionByID(ctx context.Context, id int32) (BookmarkCollection, error)
 	FindCommentByID(ctx context.Context, id int32) (Comment, error)
+	FindCommentByIDIncludingDeleted(ctx context.Context, id int32) (Comment, error)
+	FindCommentRevisionByID(ctx context.Context, arg FindCommentRevisionByIDParams) (CommentRevision, error)
 	FindPostByID(ctx context.Context, id int32) (Post, error)
+	FindPostByIDIncludingDeleted(ctx context.Context, id int32) (Post, error)
+	FindPostRevisionByID(ctx context.Context, arg FindPostRevisionByIDParams) (PostRevision, error)
 	FindUserByEmail(ctx context.Context, email string) (User, error)
 	FindUserByID(ctx context.Context, id int32) (FindUserByIDRow, error)
+	FindUserFeedRankingByID(ctx context.Context, id int32) (string, error)
+	FindUserHideLikesByID(ctx context.Context, id int32) (bool, error)
+	FindUserRoleByID(ctx context.Context, id int32) (string, error)
+	FindUserSensitiveContentByID(ctx context.Context, id int32) (string, error)
+	FindVisiblePollByPostID(ctx context.Context, arg FindVisiblePollByPostIDParams) (Poll, error)
+	FindVisiblePostByID(ctx context.Context, arg FindVisiblePostByIDParams) (Post, error)
 	FollowUser(ctx context.Context, arg FollowUserParams) (Follow, error)
 	GetChat(ctx context.Context, id int32) (Chat, error)
 	GetChatByTwoUsers(ctx context.Context, arg GetChatByTwoUsersParams) (Chat, error)
 	GetChatParticipantByChatIDAndUserID(ctx context.Context, arg GetChatParticipantByChatIDAndUserIDParams) (ChatParticipant, error)
 	GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow, error)
+	HandleExists(ctx context.Context, handle string) (bool, error)
+	HideComment(ctx context.Context, id int32) (Comment, error)
+	InsertExploreSnapshot(ctx context.Context, arg InsertExploreSnapshotParams) error
+	IsBlockedEitherWay(ctx context.Context, arg IsBlockedEitherWayParams) (bool, error)
+	IsCommentVisible(ctx context.Context, arg IsCommentVisibleParams) (bool, error)
+	IsPostVisible(ctx context.Context, arg IsPostVisibleParams) (bool, error)
+	LatestExploreSnapshotAt(ctx context.Context) (pgtype.Timestamptz, error)
+	LikeComment(ctx context.Context, arg LikeCommentParams) (CommentLike, error)
 	LikePost(ctx context.Context, arg LikePostParams) (Like, error)
+	ListAttachmentsByPostIDs(ctx context.Context, postIds []int32) ([]Attachment, error)
+	ListBlockedUsers(ctx context.Context, arg ListBlockedUsersParams) ([]Block, error)
+	ListBookmarkCollectionsByUserID(ctx context.Context, userID int32) ([]BookmarkCollection, error)
+	ListBookmarkedPostIDs(ctx context.Context, arg ListBookmarkedPostIDsParams) ([]int32, error)
+	ListBookmarksByUserID(ctx context.Context, arg ListBookmarksByUserIDParams) ([]ListBookmarksByUserIDRow, error)
 	ListChatParticipantsByChatID(ctx context.Context, arg ListChatParticipantsByChatIDParams) ([]ChatParticipant, error)
 	ListChatsByUserID(ctx context.Context, arg ListChatsByUserIDParams) ([]Chat, error)
-	ListCommentsByPostID(ctx context.Context, arg ListCommentsByPostIDParams) ([]Comment, error)
+	ListCommentRevisions(ctx context.Context, arg ListCommentRevisionsParams) ([]CommentRevision, error)
+	ListCommentSubtree(ctx context.Context, arg ListCommentSubtreeParams) ([]Comment, error)
+	ListCommentsByPostID(ctx context.Context, arg ListCommentsByPostIDParams) ([]ListCommentsByPostIDRow, error)
+	ListCommentsByPostIDIncludingDeleted(ctx context.Context, arg ListCommentsByPostIDIncludingDeletedParams) ([]Comment, error)
+	ListDraftsByUserID(ctx context.Context, arg ListDraftsByUserIDParams) ([]Post, error)
+	ListExploreCandidates(ctx context.Context, arg ListExploreCandidatesParams) ([]ListExploreCandidatesRow, error)
+	ListExplorePosts(ctx context.Context, arg ListExplorePostsParams) ([]ListExplorePostsRow, error)
+	ListFeedRankingWeights(ctx context.Context) ([]FeedRankingWeight, error)
 	ListFollowers(ctx context.Context, arg ListFollowersParams) ([]Follow, error)
 	ListFollowing(ctx context.Context, arg ListFollowingParams) ([]Follow, error)
+	ListLikedCommentIDs(ctx context.Context, arg ListLikedCommentIDsParams) ([]int32, error)
+	ListLikedPostsByUserID(ctx context.Context, arg ListLikedPostsByUserIDParams) ([]ListLikedPostsByUserIDRow, error)
 	ListLikesByPostID(ctx context.Context, arg ListLikesByPostIDParams) ([]Like, error)
+	ListLinkPreviewsByPostIDs(ctx context.Context, postIds []int32) ([]ListLinkPreviewsByPostIDsRow, error)
+	ListMentionsByCommentIDs(ctx context.Context, commentIds []int32) ([]ListMentionsByCommentIDsRow, error)
+	ListMentionsByMessageIDs(ctx context.Context, messageIds []int32) ([]ListMentionsByMessageIDsRow, error)
+	ListMentionsByPostIDs(ctx context.Context, postIds []int32) ([]ListMentionsByPostIDsRow, error)
+	ListMentionsByUserID(ctx context.Context, arg ListMentionsByUserIDParams) ([]ListMentionsByUserIDRow, error)
 	ListMessagesByChatID(ctx context.Context, arg ListMessagesByChatIDParams) ([]Message, error)
-	ListPostsByUserID(ctx context.Context, arg ListPostsByUserIDParams) ([]Post, error)
+	ListMutedUsers(ctx context.Context, arg ListMutedUsersParams) ([]Mute, error)
+	ListNotificationsByUserID(ctx context.Context, arg ListNotificationsByUserIDParams) ([]Notification, error)
+	ListPinnedPostIDs(ctx context.Context, postIds []int32) ([]int32, error)
+	ListPinnedPostsByUserID(ctx context.Context, userID int32) ([]Post, error)
+	ListPollOptionsByPollIDs(ctx context.Context, pollIds []int32) ([]ListPollOptionsByPollIDsRow, error)
+	ListPollVotesByUserID(ctx context.Context, arg ListPollVotesByUserIDParams) ([]ListPollVotesByUserIDRow, error)
+	ListPollsByPostIDs(ctx context.Context, postIds []int32) ([]Poll, error)
+	ListPostReactions(ctx context.Context, arg ListPostReactionsParams) ([]Like, error)
+	ListPostRevisions(ctx context.Context, arg ListPostRevisionsParams) ([]PostRevision, error)
+	ListPostsByHashtag(ctx context.Context, arg ListPostsByHashtagParams) ([]Post, error)
+	ListPostsByIDsIncludingDeleted(ctx context.Context, ids []int32) ([]Post, error)
+	ListPostsByUserID(ctx context.Context, arg ListPostsByUserIDParams) ([]ListPostsByUserIDRow, error)
+	ListReplies(ctx context.Context, arg ListRepliesParams) ([]Comment, error)
+	ListRepliesByParentIDs(ctx context.Context, arg ListRepliesByParentIDsParams) ([]Comment, error)
+	ListTrashedCommentsByUserID(ctx context.Context, arg ListTrashedCommentsByUserIDParams) ([]Comment, error)
+	ListTrashedPostsByUserID(ctx context.Context, arg ListTrashedPostsByUserIDParams) ([]Post, error)
+	ListTrendingHashtags(ctx context.Context, arg ListTrendingHashtagsParams) ([]ListTrendingHashtagsRow, error)
 	ListUsers(ctx context.Context) ([]ListUsersRow, error)
+	ListViewCountsByPostIDs(ctx context.Context, postIds []int32) ([]PostViewCount, error)
+	ListViewerCommentReactions(ctx context.Context, arg ListViewerCommentReactionsParams) ([]ListViewerCommentReactionsRow, error)
+	ListViewerPostReactions(ctx context.Context, arg ListViewerPostReactionsParams) ([]ListViewerPostReactionsRow, error)
+	LockPinnedPosts(ctx context.Context, userID int32) error
+	MarkNotificationsRead(ctx context.Context, userID int32) error
+	MuteUser(ctx context.Context, arg MuteUserParams) (Mute, error)
+	PinPost(ctx context.Context, arg PinPostParams) (int64, error)
+	PublishDuePosts(ctx context.Context, limit int32) ([]Post, error)
+	PurgeDeletedComments(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	PurgeDeletedPosts(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	QueueTimelineFanout(ctx context.Context, postIds []int32) error
+	ReactToComment(ctx context.Context, arg ReactToCommentParams) (CommentLike, error)
+	ReactToPost(ctx context.Context, arg ReactToPostParams) (Like, error)
+	RecordImpressions(ctx context.Context, arg RecordImpressionsParams) error
+	RemoveCommentReaction(ctx context.Context, arg RemoveCommentReactionParams) error
+	RemovePostReaction(ctx context.Context, arg RemovePostReactionParams) error
+	RenameBookmarkCollection(ctx context.Context, arg RenameBookmarkCollectionParams) (BookmarkCollection, error)
+	ReorderBookmarkCollections(ctx context.Context, arg ReorderBookmarkCollectionsParams) (int64, error)
+	ReorderPinnedPosts(ctx context.Context, arg ReorderPinnedPostsParams) (int64, error)
+	RepostPost(ctx context.Context, arg RepostPostParams) (Repost, error)
+	ResolveMentions(ctx context.Context, arg ResolveMentionsParams) ([]ResolveMentionsRow, error)
+	RestoreComment(ctx context.Context, id int32) (Comment, error)
+	RestorePost(ctx context.Context, id int32) (Post, error)
+	SaveLinkPreview(ctx context.Context, arg SaveLinkPreviewParams) error
+	SearchComments(ctx context.Context, arg SearchCommentsParams) ([]SearchCommentsRow, error)
+	SearchPosts(ctx context.Context, arg SearchPostsParams) ([]SearchPostsRow, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SetCommentContentHTML(ctx context.Context, arg SetCommentContentHTMLParams) error
+	SetCommentMentions(ctx context.Context, arg SetCommentMentionsParams) error
+	SetPostCommentSettings(ctx context.Context, arg SetPostCommentSettingsParams) (Post, error)
+	SetPostContentHTML(ctx context.Context, arg SetPostContentHTMLParams) error
+	SetPostFlags(ctx context.Context, arg SetPostFlagsParams) (Post, error)
+	SetPostHashtags(ctx context.Context, arg SetPostHashtagsParams) error
+	SetPostLink(ctx context.Context, arg SetPostLinkParams) error
+	SetPostMentions(ctx context.Context, arg SetPostMentionsParams) error
+	SetUserFanoutOnRead(ctx context.Context, id int32) error
+	TryLockExploreSnapshot(ctx context.Context) (bool, error)
+	UnblockUser(ctx context.Context, arg UnblockUserParams) (int64, error)
+	UndoRepost(ctx context.Context, arg UndoRepostParams) (int64, error)
 	UnfollowUser(ctx context.Context, arg UnfollowUserParams) error
+	UnhideComment(ctx context.Context, id int32) (Comment, error)
+	UnlikeComment(ctx context.Context, arg UnlikeCommentParams) error
 	UnlikePost(ctx context.Context, arg UnlikePostParams) error
+	UnmuteUser(ctx context.Context, arg UnmuteUserParams) (int64, error)
+	UnpinPost(ctx context.Context, arg UnpinPostParams) (int64, error)
+	UpdateAttachmentAltText(ctx context.Context, arg UpdateAttachmentAltTextParams) (Attachment, error)
 	UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error)
+	UpdateFeedRankingWeights(ctx context.Context, arg UpdateFeedRankingWeightsParams) (FeedRankingWeight, error)
 	UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error)
 }
@@ -0,0 +1,33 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (user_id, kind, mime_type, size_bytes, width, height, storage_key, blurhash, alt_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: FindAttachmentByID :one
+SELECT * FROM attachments WHERE id = $1;
+
+-- name: UpdateAttachmentAltText :one
+UPDATE attachments SET alt_text = $2 WHERE id = $1 RETURNING *;
+
+-- name: CountUnattachedAttachments :one
+SELECT COUNT(*) FROM attachments
+WHERE id = ANY(sqlc.arg('ids')::int[]) AND user_id = sqlc.arg('user_id') AND post_id IS NULL;
+
+-- name: AttachToPost :execrows
+UPDATE attachments
+SET post_id = sqlc.arg('post_id'), position = array_position(sqlc.arg('ids')::int[], id)
+WHERE id = ANY(sqlc.arg('ids')::int[]) AND user_id = sqlc.arg('user_id') AND post_id IS NULL;
+
+-- name: ListAttachmentsByPostIDs :many
+SELECT * FROM attachments WHERE post_id = ANY(sqlc.arg('post_ids')::int[]) ORDER BY post_id, position;
+
+-- name: DeleteOrphanedAttachments :many
+DELETE FROM attachments
+WHERE id IN (
+    SELECT id FROM attachments
+    WHERE post_id IS NULL AND created_at < $1
+    ORDER BY created_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
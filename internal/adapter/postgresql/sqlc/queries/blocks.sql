@@ -0,0 +1,36 @@
+-- name: BlockUser :one
+WITH unfollowed AS (
+    DELETE FROM follows
+    WHERE (follower_id = sqlc.arg('blocker_id') AND following_id = sqlc.arg('blocked_id'))
+       OR (follower_id = sqlc.arg('blocked_id') AND following_id = sqlc.arg('blocker_id'))
+), pruned AS (
+    DELETE FROM timelines
+    WHERE (user_id = sqlc.arg('blocker_id') AND author_id = sqlc.arg('blocked_id'))
+       OR (user_id = sqlc.arg('blocked_id') AND author_id = sqlc.arg('blocker_id'))
+)
+INSERT INTO blocks (blocker_id, blocked_id)
+VALUES (sqlc.arg('blocker_id'), sqlc.arg('blocked_id'))
+RETURNING blocker_id, blocked_id, created_at;
+
+-- name: UnblockUser :execrows
+DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: ListBlockedUsers :many
+SELECT * FROM blocks
+WHERE blocker_id = sqlc.arg('blocker_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, blocked_id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, blocked_id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN blocked_id END,
+    created_at DESC,
+    blocked_id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: IsBlockedEitherWay :one
+SELECT EXISTS (
+    SELECT 1 FROM blocks
+    WHERE (blocker_id = $1 AND blocked_id = $2)
+       OR (blocker_id = $2 AND blocked_id = $1)
+);
@@ -0,0 +1,60 @@
+-- name: BookmarkPost :one
+INSERT INTO bookmarks (user_id, post_id, collection_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, post_id) DO UPDATE SET collection_id = EXCLUDED.collection_id
+RETURNING *;
+
+-- name: DeleteBookmark :execrows
+DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2;
+
+-- name: ListBookmarksByUserID :many
+SELECT
+    b.id AS bookmark_id,
+    b.collection_id,
+    b.created_at AS bookmarked_at,
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive
+FROM bookmarks b
+JOIN posts p ON p.id = b.post_id
+WHERE b.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('collection_id')::int IS NULL OR b.collection_id = sqlc.narg('collection_id'))
+  AND p.status = 'published' AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('user_id'))
+  AND (sqlc.arg('cursor_id')::int = 0
+    OR (NOT sqlc.arg('backward')::bool AND b.id < sqlc.arg('cursor_id')::int)
+    OR (sqlc.arg('backward')::bool AND b.id > sqlc.arg('cursor_id')::int))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN b.id END,
+    b.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListBookmarkedPostIDs :many
+SELECT post_id FROM bookmarks
+WHERE user_id = sqlc.arg('user_id') AND post_id = ANY(sqlc.arg('post_ids')::int[]);
+
+-- name: CreateBookmarkCollection :one
+INSERT INTO bookmark_collections (user_id, name, position)
+SELECT sqlc.arg('user_id'), sqlc.arg('name'), COALESCE(MAX(position), 0) + 1
+FROM bookmark_collections
+WHERE user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: FindBookmarkCollectionByID :one
+SELECT * FROM bookmark_collections WHERE id = $1;
+
+-- name: ListBookmarkCollectionsByUserID :many
+SELECT * FROM bookmark_collections WHERE user_id = $1 ORDER BY position, id;
+
+-- name: CountBookmarkCollectionsByUserID :one
+SELECT COUNT(*) FROM bookmark_collections WHERE user_id = $1;
+
+-- name: RenameBookmarkCollection :one
+UPDATE bookmark_collections SET name = $2 WHERE id = $1 RETURNING *;
+
+-- name: ReorderBookmarkCollections :execrows
+UPDATE bookmark_collections c
+SET position = o.position
+FROM unnest(sqlc.arg('ids')::int[]) WITH ORDINALITY AS o(id, position)
+WHERE c.id = o.id AND c.user_id = sqlc.arg('user_id');
+
+-- name: DeleteBookmarkCollection :exec
+DELETE FROM bookmark_collections WHERE id = $1;
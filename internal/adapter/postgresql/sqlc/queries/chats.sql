@@ -12,9 +12,16 @@ JOIN chat_participants cp2 ON cp2.chat_id = c.id AND cp2.user_id = $2;
 -- name: ListChatsByUserID :many
 SELECT c.id, c.created_at FROM chats c
 JOIN chat_participants cp ON cp.chat_id = c.id
-WHERE cp.user_id = $1
-ORDER BY c.created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE cp.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (c.created_at, c.id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (c.created_at, c.id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN c.created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN c.id END,
+    c.created_at DESC,
+    c.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: DeleteChat :exec
 DELETE FROM chats WHERE id = $1;
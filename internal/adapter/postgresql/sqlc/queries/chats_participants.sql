@@ -5,7 +5,17 @@ INSERT INTO chat_participants (chat_id, user_id, joined_at) VALUES ($1, $2, $3);
 SELECT * FROM chat_participants WHERE chat_id = $1 AND user_id = $2;
 
 -- name: ListChatParticipantsByChatID :many
-SELECT * FROM chat_participants WHERE chat_id = $1 ORDER BY joined_at LIMIT $2 OFFSET $3;
+SELECT * FROM chat_participants
+WHERE chat_id = sqlc.arg('chat_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (joined_at, user_id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (joined_at, user_id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN joined_at END DESC,
+    CASE WHEN sqlc.arg('backward')::bool THEN user_id END DESC,
+    joined_at,
+    user_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: DeleteChatParticipant :exec
 DELETE FROM chat_participants WHERE chat_id = $1 AND user_id = $2;
@@ -0,0 +1,17 @@
+-- name: LikeComment :one
+INSERT INTO comment_likes (user_id, comment_id) VALUES ($1, $2)
+ON CONFLICT (user_id, comment_id) DO UPDATE SET reaction = 'like' WHERE comment_likes.reaction <> 'like'
+RETURNING id, user_id, comment_id, created_at, reaction;
+
+-- name: UnlikeComment :exec
+DELETE FROM comment_likes WHERE user_id = $1 AND comment_id = $2 AND reaction = 'like';
+
+-- name: CountLikesByCommentIDs :many
+SELECT comment_id, COUNT(*) AS count
+FROM comment_likes
+WHERE comment_id = ANY(sqlc.arg('comment_ids')::int[]) AND reaction = 'like'
+GROUP BY comment_id;
+
+-- name: ListLikedCommentIDs :many
+SELECT comment_id FROM comment_likes
+WHERE user_id = $1 AND comment_id = ANY(sqlc.arg('comment_ids')::int[]) AND reaction = 'like';
@@ -1,22 +1,169 @@
 -- name: ListCommentsByPostID :many
-SELECT * FROM comments WHERE post_id = $1 ORDER BY created_at ASC LIMIT $2 OFFSET $3;
+SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by, k.rank, k.tiebreak FROM comments c
+JOIN posts p ON p.id = c.post_id
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS likes_count FROM comment_likes cl
+    WHERE cl.comment_id = c.id AND cl.reaction = 'like' AND sqlc.arg('sort')::text = 'top'
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS replies_count FROM comments rc
+    WHERE rc.parent_id = c.id AND rc.deleted_at IS NULL AND rc.hidden_at IS NULL AND sqlc.arg('sort')::text = 'top'
+) r
+CROSS JOIN LATERAL (
+    SELECT
+        (CASE sqlc.arg('sort')::text
+            WHEN 'top' THEN
+                l.likes_count * 0.5
+                + r.replies_count * 2
+                - EXTRACT(EPOCH FROM (sqlc.arg('as_of')::timestamptz - c.created_at)) / 3600
+            WHEN 'newest' THEN EXTRACT(EPOCH FROM c.created_at)
+            ELSE -EXTRACT(EPOCH FROM c.created_at)
+        END)::float8 AS rank,
+        (CASE WHEN sqlc.arg('sort')::text IN ('top', 'newest') THEN c.id ELSE -c.id END)::int AS tiebreak
+) k
+WHERE c.post_id = sqlc.arg('post_id') AND c.parent_id IS NULL AND c.deleted_at IS NULL AND p.deleted_at IS NULL
+  AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (k.rank, k.tiebreak) < (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (k.rank, k.tiebreak) > (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN k.rank END,
+    CASE WHEN sqlc.arg('backward')::bool THEN k.tiebreak END,
+    k.rank DESC,
+    k.tiebreak DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListCommentsByPostIDIncludingDeleted :many
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by FROM comments
+WHERE post_id = sqlc.arg('post_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END DESC,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END DESC,
+    created_at,
+    id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListTrashedCommentsByUserID :many
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by FROM comments
+WHERE user_id = sqlc.arg('user_id') AND deleted_at IS NOT NULL AND (deleted_by IS NULL OR deleted_by = user_id)
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (deleted_at, 'comment'::text, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_type')::text, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (deleted_at, 'comment'::text, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_type')::text, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN deleted_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    deleted_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountCommentsByPostID :one
-SELECT COUNT(*) FROM comments WHERE post_id = $1;
+SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL AND hidden_at IS NULL;
 
 -- name: CreateComment :one
-INSERT INTO comments (post_id, user_id, content) VALUES ($1, $2, $3) RETURNING id, post_id, user_id, content, created_at, updated_at;
+INSERT INTO comments (post_id, user_id, content, language, parent_id, depth)
+VALUES (
+    $1, $2, $3,
+    COALESCE(sqlc.narg('language')::regconfig, (SELECT language FROM posts WHERE id = $1)),
+    sqlc.narg('parent_id'),
+    COALESCE((SELECT depth + 1 FROM comments WHERE id = sqlc.narg('parent_id')), 0)
+)
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by;
 
 -- name: FindCommentByID :one
-SELECT * FROM comments WHERE id = $1;
+SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+JOIN posts p ON p.id = c.post_id
+WHERE c.id = $1 AND c.deleted_at IS NULL AND p.deleted_at IS NULL;
+
+-- name: FindCommentByIDIncludingDeleted :one
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by FROM comments WHERE id = $1;
 
 -- name: UpdateComment :one
+WITH revision AS (
+    INSERT INTO comment_revisions (comment_id, content, created_at)
+    SELECT c.id, c.content, c.updated_at
+    FROM comments c
+    WHERE c.id = sqlc.arg('id')
+      AND c.content <> COALESCE(sqlc.narg('content'), c.content)
+)
 UPDATE comments
 SET
     content = COALESCE(sqlc.narg('content'), content),
+    edited = edited OR content <> COALESCE(sqlc.narg('content'), content),
     updated_at = NOW()
 WHERE id = sqlc.arg('id')
-RETURNING id, post_id, user_id, content, created_at, updated_at;
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by;
 
 -- name: DeleteComment :exec
-DELETE FROM comments WHERE id = $1;
+UPDATE comments SET deleted_at = NOW(), deleted_by = sqlc.arg('deleted_by') WHERE id = sqlc.arg('id') AND deleted_at IS NULL;
+
+-- name: RestoreComment :one
+UPDATE comments SET deleted_at = NULL, deleted_by = NULL WHERE id = $1 RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by;
+
+-- name: PurgeDeletedComments :execrows
+DELETE FROM comments WHERE deleted_at < $1;
+
+-- name: SetCommentContentHTML :exec
+UPDATE comments SET content_html = $2 WHERE id = $1;
+
+-- name: ListRepliesByParentIDs :many
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+FROM (
+    SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by, ROW_NUMBER() OVER (PARTITION BY c.parent_id ORDER BY c.id) AS n
+    FROM comments c
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.parent_id = ANY(sqlc.arg('parent_ids')::int[]) AND c.deleted_at IS NULL
+      AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+) r
+WHERE r.n <= sqlc.arg('replies_per_parent')::int
+ORDER BY r.parent_id, r.id;
+
+-- name: ListReplies :many
+SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+JOIN posts p ON p.id = c.post_id
+WHERE c.parent_id = sqlc.arg('parent_id')::int
+  AND c.deleted_at IS NULL
+  AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+  AND (sqlc.arg('cursor_id')::int = 0
+    OR (NOT sqlc.arg('backward')::bool AND c.id > sqlc.arg('cursor_id')::int)
+    OR (sqlc.arg('backward')::bool AND c.id < sqlc.arg('cursor_id')::int))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN c.id END DESC,
+    c.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListCommentSubtree :many
+WITH RECURSIVE tree AS (
+    SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.parent_id = sqlc.arg('root_id')::int AND c.deleted_at IS NULL
+      AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+    UNION ALL
+    SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by FROM comments c
+    JOIN tree t ON c.parent_id = t.id
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.deleted_at IS NULL
+      AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+)
+SELECT id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by
+FROM tree
+ORDER BY depth, id
+LIMIT sqlc.arg('limit');
+
+-- name: CountRepliesByCommentIDs :many
+SELECT c.parent_id::int AS comment_id, COUNT(*) AS count
+FROM comments c
+JOIN posts p ON p.id = c.post_id
+WHERE c.parent_id = ANY(sqlc.arg('comment_ids')::int[]) AND c.deleted_at IS NULL
+  AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+GROUP BY c.parent_id;
+
+-- name: HideComment :one
+UPDATE comments SET hidden_at = COALESCE(hidden_at, NOW()) WHERE id = $1
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by;
+
+-- name: UnhideComment :one
+UPDATE comments SET hidden_at = NULL WHERE id = $1
+RETURNING id, post_id, user_id, content, created_at, updated_at, edited, deleted_at, content_html, language, parent_id, depth, hidden_at, deleted_by;
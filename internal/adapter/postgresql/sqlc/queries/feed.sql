@@ -1,15 +1,172 @@
 -- name: GetFeed :many
+WITH followed_reposts AS (
+    SELECT
+        r.post_id,
+        array_agg(r.user_id ORDER BY r.created_at DESC)::int[] AS reposted_by,
+        MAX(r.created_at)::timestamptz AS reposted_at
+    FROM reposts r
+    JOIN follows f ON f.following_id = r.user_id AND f.follower_id = sqlc.arg('follower_id')
+    WHERE r.created_at <= sqlc.arg('as_of')
+      AND NOT EXISTS (
+        SELECT 1 FROM mutes m WHERE m.muter_id = sqlc.arg('follower_id') AND m.muted_id = r.user_id
+      )
+    GROUP BY r.post_id
+), entries AS (
+    SELECT t.post_id FROM timelines t WHERE t.user_id = sqlc.arg('follower_id')
+    UNION
+    SELECT p.id
+    FROM follows f
+    JOIN users u ON u.id = f.following_id AND u.fanout_on_read
+    JOIN posts p ON p.user_id = f.following_id
+    WHERE f.follower_id = sqlc.arg('follower_id')
+      AND p.status = 'published'
+      AND p.deleted_at IS NULL
+      AND COALESCE(p.publish_at, p.created_at) > sqlc.arg('since')
+    UNION
+    SELECT post_id FROM followed_reposts
+)
 SELECT
-    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at,
-    COALESCE(l.likes_count, 0)::bigint AS likes_count,
-    COALESCE(c.comments_count, 0)::bigint AS comments_count,
-    (COALESCE(l.likes_count, 0) * 0.5
-     + COALESCE(c.comments_count, 0) * 2
-     - EXTRACT(EPOCH FROM (NOW() - p.created_at)) / 3600
-    )::float8 AS score
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive,
+    l.likes_count,
+    c.comments_count,
+    COALESCE(fr.reposted_by, '{}')::int[] AS reposted_by,
+    fr.reposted_at,
+    a.active_at,
+    s.score
+FROM entries e
+JOIN posts p ON p.id = e.post_id
+LEFT JOIN followed_reposts fr ON fr.post_id = p.id
+CROSS JOIN LATERAL (
+    SELECT GREATEST(COALESCE(p.publish_at, p.created_at), fr.reposted_at)::timestamptz AS active_at
+) a
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS likes_count FROM likes WHERE post_id = p.id AND reaction = 'like'
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS comments_count FROM comments
+    WHERE post_id = p.id AND deleted_at IS NULL AND hidden_at IS NULL
+) c
+CROSS JOIN LATERAL (
+    SELECT
+        sqlc.arg('likes_weight')::float8 * l.likes_count + sqlc.arg('comments_weight')::float8 * c.comments_count AS engagement,
+        GREATEST(EXTRACT(EPOCH FROM (sqlc.arg('as_of')::timestamptz - a.active_at)) / 3600, 0)::float8 AS hours
+) w
+CROSS JOIN LATERAL (
+    SELECT (CASE sqlc.arg('ranking')::text
+        WHEN 'weighted' THEN w.engagement - sqlc.arg('age_weight')::float8 * w.hours
+        WHEN 'decayed' THEN (1 + w.engagement) / power(w.hours + 2, sqlc.arg('gravity')::float8)
+        ELSE EXTRACT(EPOCH FROM a.active_at)
+    END)::float8 AS score
+) s
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+  AND a.active_at <= sqlc.arg('as_of')
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('follower_id'))
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = sqlc.arg('follower_id') AND b.blocked_id = p.user_id)
+       OR (b.blocker_id = p.user_id AND b.blocked_id = sqlc.arg('follower_id'))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes m WHERE m.muter_id = sqlc.arg('follower_id') AND m.muted_id = p.user_id
+  )
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (s.score, p.id) < (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (s.score, p.id) > (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN s.score END,
+    CASE WHEN sqlc.arg('backward')::bool THEN p.id END,
+    s.score DESC,
+    p.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListFeedRankingWeights :many
+SELECT * FROM feed_ranking_weights ORDER BY ranking;
+
+-- name: UpdateFeedRankingWeights :one
+UPDATE feed_ranking_weights
+SET likes = $2, comments = $3, age = $4, gravity = $5, updated_at = NOW()
+WHERE ranking = $1
+RETURNING ranking, likes, comments, age, gravity, updated_at;
+
+-- name: ListExploreCandidates :many
+SELECT
+    p.id AS post_id,
+    p.user_id AS author_id,
+    COALESCE(p.publish_at, p.created_at)::timestamptz AS published_at,
+    l.reactions_count,
+    c.comments_count,
+    r.reposts_count
 FROM posts p
-JOIN follows f ON p.user_id = f.following_id AND f.follower_id = $1
-LEFT JOIN (SELECT post_id, COUNT(*) AS likes_count FROM likes GROUP BY post_id) l ON l.post_id = p.id
-LEFT JOIN (SELECT post_id, COUNT(*) AS comments_count FROM comments GROUP BY post_id) c ON c.post_id = p.id
-ORDER BY score DESC
-LIMIT $2 OFFSET $3;
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS reactions_count FROM likes WHERE post_id = p.id
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS comments_count FROM comments
+    WHERE post_id = p.id AND deleted_at IS NULL AND hidden_at IS NULL
+) c
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS reposts_count FROM reposts WHERE post_id = p.id
+) r
+WHERE p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND p.visibility = 'public'
+  AND COALESCE(p.publish_at, p.created_at) > sqlc.arg('since')
+  AND l.reactions_count + c.comments_count + r.reposts_count > 0
+ORDER BY published_at DESC
+LIMIT sqlc.arg('limit');
+
+-- name: TryLockExploreSnapshot :one
+SELECT pg_try_advisory_xact_lock(hashtext('explore_posts'));
+
+-- name: LatestExploreSnapshotAt :one
+SELECT MAX(computed_at)::timestamptz AS computed_at FROM explore_posts;
+
+-- name: InsertExploreSnapshot :exec
+INSERT INTO explore_posts (computed_at, post_id, author_id, score)
+SELECT sqlc.arg('computed_at'), e.post_id, e.author_id, e.score
+FROM unnest(sqlc.arg('post_ids')::int[], sqlc.arg('author_ids')::int[], sqlc.arg('scores')::float8[]) AS e(post_id, author_id, score);
+
+-- name: DeleteExploreSnapshotsBefore :exec
+DELETE FROM explore_posts WHERE computed_at < $1;
+
+-- name: ListExplorePosts :many
+WITH snapshot AS (
+    SELECT COALESCE(sqlc.narg('cursor_time')::timestamptz, MAX(computed_at)) AS computed_at
+    FROM explore_posts
+)
+SELECT
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive,
+    e.score,
+    e.computed_at,
+    l.likes_count,
+    c.comments_count
+FROM snapshot s
+JOIN explore_posts e ON e.computed_at = s.computed_at
+JOIN posts p ON p.id = e.post_id
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS likes_count FROM likes WHERE post_id = p.id AND reaction = 'like'
+) l
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::bigint AS comments_count FROM comments
+    WHERE post_id = p.id AND deleted_at IS NULL AND hidden_at IS NULL
+) c
+WHERE p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND p.visibility = 'public'
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = sqlc.arg('viewer_id') AND b.blocked_id = p.user_id)
+       OR (b.blocker_id = p.user_id AND b.blocked_id = sqlc.arg('viewer_id'))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes m WHERE m.muter_id = sqlc.arg('viewer_id') AND m.muted_id = p.user_id
+  )
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (e.score, p.id) < (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (e.score, p.id) > (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN e.score END,
+    CASE WHEN sqlc.arg('backward')::bool THEN p.id END,
+    e.score DESC,
+    p.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
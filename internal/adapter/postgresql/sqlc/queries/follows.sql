@@ -1,17 +1,58 @@
 -- name: FollowUser :one
-INSERT INTO follows (follower_id, following_id) VALUES ($1, $2) RETURNING id, follower_id, following_id, created_at;
+WITH followed AS (
+    INSERT INTO follows (follower_id, following_id)
+    VALUES (sqlc.arg('follower_id'), sqlc.arg('following_id'))
+    RETURNING id, follower_id, following_id, created_at
+), backfilled AS (
+    INSERT INTO timelines (user_id, post_id, author_id, created_at)
+    SELECT f.follower_id, p.id, p.user_id, COALESCE(p.publish_at, p.created_at)
+    FROM followed f
+    JOIN posts p ON p.user_id = f.following_id
+    JOIN users u ON u.id = p.user_id
+    WHERE NOT u.fanout_on_read
+      AND p.status = 'published'
+      AND p.deleted_at IS NULL
+      AND COALESCE(p.publish_at, p.created_at) > sqlc.arg('since')
+    ORDER BY COALESCE(p.publish_at, p.created_at) DESC
+    LIMIT sqlc.arg('limit')
+    ON CONFLICT (user_id, post_id) DO NOTHING
+)
+SELECT id, follower_id, following_id, created_at FROM followed;
 
 -- name: UnfollowUser :exec
+WITH pruned AS (
+    DELETE FROM timelines WHERE user_id = $1 AND author_id = $2
+)
 DELETE FROM follows WHERE follower_id = $1 AND following_id = $2;
 
 -- name: ListFollowers :many
-SELECT * FROM follows WHERE following_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3;
+SELECT * FROM follows
+WHERE following_id = sqlc.arg('following_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountFollowers :one
 SELECT COUNT(*) FROM follows WHERE following_id = $1;
 
 -- name: ListFollowing :many
-SELECT * FROM follows WHERE follower_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3;
+SELECT * FROM follows
+WHERE follower_id = sqlc.arg('follower_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountFollowing :one
 SELECT COUNT(*) FROM follows WHERE follower_id = $1;
@@ -0,0 +1,55 @@
+-- name: SetPostHashtags :exec
+WITH tags AS (
+    INSERT INTO hashtags (name)
+    SELECT DISTINCT unnest(sqlc.arg('names')::text[])
+    ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+    RETURNING id
+),
+removed AS (
+    DELETE FROM post_hashtags
+    WHERE post_id = sqlc.arg('post_id') AND hashtag_id NOT IN (SELECT id FROM tags)
+)
+INSERT INTO post_hashtags (post_id, hashtag_id)
+SELECT sqlc.arg('post_id'), id FROM tags
+ON CONFLICT (post_id, hashtag_id) DO NOTHING;
+
+-- name: ListPostsByHashtag :many
+SELECT p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive
+FROM posts p
+JOIN post_hashtags ph ON ph.post_id = p.id
+JOIN hashtags h ON h.id = ph.hashtag_id
+WHERE h.name = sqlc.arg('name')
+  AND p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (COALESCE(p.publish_at, p.created_at), p.id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (COALESCE(p.publish_at, p.created_at), p.id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN COALESCE(p.publish_at, p.created_at) END,
+    CASE WHEN sqlc.arg('backward')::bool THEN p.id END,
+    COALESCE(p.publish_at, p.created_at) DESC,
+    p.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListTrendingHashtags :many
+SELECT
+    h.name,
+    COUNT(DISTINCT p.user_id) FILTER (WHERE COALESCE(p.publish_at, p.created_at) >= sqlc.arg('window_start')::timestamptz)::bigint AS recent_uses,
+    COUNT(DISTINCT p.user_id) FILTER (WHERE COALESCE(p.publish_at, p.created_at) < sqlc.arg('window_start')::timestamptz)::bigint AS previous_uses
+FROM hashtags h
+JOIN post_hashtags ph ON ph.hashtag_id = h.id
+JOIN posts p ON p.id = ph.post_id
+WHERE p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND p.visibility = 'public'
+  AND COALESCE(p.publish_at, p.created_at) >= sqlc.arg('previous_start')::timestamptz
+  AND COALESCE(p.publish_at, p.created_at) <= NOW()
+GROUP BY h.id, h.name
+HAVING COUNT(DISTINCT p.user_id) FILTER (WHERE COALESCE(p.publish_at, p.created_at) >= sqlc.arg('window_start')::timestamptz) >= sqlc.arg('min_uses')::bigint
+ORDER BY
+    COUNT(DISTINCT p.user_id) FILTER (WHERE COALESCE(p.publish_at, p.created_at) >= sqlc.arg('window_start')::timestamptz)
+    - COUNT(DISTINCT p.user_id) FILTER (WHERE COALESCE(p.publish_at, p.created_at) < sqlc.arg('window_start')::timestamptz) DESC,
+    recent_uses DESC,
+    h.name
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,19 @@
+-- name: RecordImpressions :exec
+WITH inserted AS (
+    INSERT INTO post_impressions (post_id, viewer_id, window_start)
+    SELECT i.post_id, i.viewer_id, i.window_start
+    FROM unnest(sqlc.arg('post_ids')::int[], sqlc.arg('viewer_ids')::int[], sqlc.arg('window_starts')::timestamptz[]) AS i(post_id, viewer_id, window_start)
+    JOIN posts p ON p.id = i.post_id AND p.user_id <> i.viewer_id
+    JOIN users u ON u.id = i.viewer_id
+    ON CONFLICT DO NOTHING
+    RETURNING post_id
+)
+INSERT INTO post_view_counts (post_id, views_count)
+SELECT post_id, COUNT(*) FROM inserted GROUP BY post_id
+ON CONFLICT (post_id) DO UPDATE SET views_count = post_view_counts.views_count + EXCLUDED.views_count;
+
+-- name: DeleteExpiredImpressions :execrows
+DELETE FROM post_impressions WHERE window_start < $1;
+
+-- name: ListViewCountsByPostIDs :many
+SELECT post_id, views_count FROM post_view_counts WHERE post_id = ANY(sqlc.arg('post_ids')::int[]);
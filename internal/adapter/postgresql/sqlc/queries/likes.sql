@@ -1,11 +1,42 @@
 -- name: LikePost :one
-INSERT INTO likes (user_id, post_id) VALUES ($1, $2) RETURNING id, user_id, post_id, created_at;
+INSERT INTO likes (user_id, post_id) VALUES ($1, $2)
+ON CONFLICT (user_id, post_id) DO UPDATE SET reaction = 'like' WHERE likes.reaction <> 'like'
+RETURNING id, user_id, post_id, created_at, reaction;
 
 -- name: UnlikePost :exec
-DELETE FROM likes WHERE user_id = $1 AND post_id = $2;
+DELETE FROM likes WHERE user_id = $1 AND post_id = $2 AND reaction = 'like';
 
 -- name: ListLikesByPostID :many
-SELECT * FROM likes WHERE post_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3;
+SELECT l.* FROM likes l
+JOIN posts p ON p.id = l.post_id
+WHERE l.post_id = sqlc.arg('post_id') AND l.reaction = 'like' AND p.deleted_at IS NULL
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (l.created_at, l.id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (l.created_at, l.id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN l.created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN l.id END,
+    l.created_at DESC,
+    l.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountLikesByPostID :one
-SELECT COUNT(*) FROM likes WHERE post_id = $1;
+SELECT COUNT(*) FROM likes WHERE post_id = $1 AND reaction = 'like';
+
+-- name: ListLikedPostsByUserID :many
+SELECT
+    l.id AS like_id,
+    l.created_at AS liked_at,
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive
+FROM likes l
+JOIN posts p ON p.id = l.post_id
+WHERE l.user_id = sqlc.arg('user_id') AND l.reaction = 'like'
+  AND p.status = 'published' AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+  AND (sqlc.arg('cursor_id')::int = 0
+    OR (NOT sqlc.arg('backward')::bool AND l.id < sqlc.arg('cursor_id')::int)
+    OR (sqlc.arg('backward')::bool AND l.id > sqlc.arg('cursor_id')::int))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN l.id END,
+    l.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,38 @@
+-- name: SetPostLink :exec
+WITH preview AS (
+    INSERT INTO link_previews (url)
+    VALUES (sqlc.arg('url'))
+    ON CONFLICT (url) DO UPDATE SET status = 'pending', claimed_at = NULL
+    WHERE link_previews.status IN ('ready', 'failed')
+      AND link_previews.fetched_at < sqlc.arg('stale_before')
+)
+INSERT INTO post_links (post_id, url)
+VALUES (sqlc.arg('post_id'), sqlc.arg('url'))
+ON CONFLICT (post_id) DO UPDATE SET url = EXCLUDED.url;
+
+-- name: DeletePostLink :exec
+DELETE FROM post_links WHERE post_id = $1;
+
+-- name: ClaimPendingLinkPreviews :many
+UPDATE link_previews
+SET status = 'fetching', claimed_at = NOW()
+WHERE url IN (
+    SELECT url FROM link_previews
+    WHERE status = 'pending'
+       OR (status = 'fetching' AND claimed_at < sqlc.arg('claim_expired_before'))
+    ORDER BY created_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING url;
+
+-- name: SaveLinkPreview :exec
+UPDATE link_previews
+SET status = $2, title = $3, description = $4, image_url = $5, site_name = $6, fetched_at = NOW()
+WHERE url = $1;
+
+-- name: ListLinkPreviewsByPostIDs :many
+SELECT pl.post_id, lp.url, lp.title, lp.description, lp.image_url, lp.site_name
+FROM post_links pl
+JOIN link_previews lp ON lp.url = pl.url
+WHERE pl.post_id = ANY(sqlc.arg('post_ids')::int[]) AND lp.status = 'ready';
@@ -0,0 +1,95 @@
+-- name: ResolveMentions :many
+SELECT u.id, u.handle
+FROM users u
+WHERE u.handle = ANY(sqlc.arg('handles')::text[])
+  AND u.id <> sqlc.arg('author_id')
+  AND (
+    u.mention_policy = 'everyone'
+    OR (u.mention_policy = 'following' AND EXISTS (
+        SELECT 1 FROM follows f WHERE f.follower_id = u.id AND f.following_id = sqlc.arg('author_id')
+    ))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = u.id AND b.blocked_id = sqlc.arg('author_id'))
+       OR (b.blocker_id = sqlc.arg('author_id') AND b.blocked_id = u.id)
+  );
+
+-- name: SetPostMentions :exec
+WITH removed AS (
+    DELETE FROM mentions
+    WHERE post_id = sqlc.arg('post_id') AND user_id <> ALL(sqlc.arg('user_ids')::int[])
+)
+INSERT INTO mentions (post_id, user_id, author_id)
+SELECT sqlc.arg('post_id'), unnest(sqlc.arg('user_ids')::int[]), sqlc.arg('author_id')
+ON CONFLICT DO NOTHING;
+
+-- name: SetCommentMentions :exec
+WITH removed AS (
+    DELETE FROM mentions
+    WHERE comment_id = sqlc.arg('comment_id') AND user_id <> ALL(sqlc.arg('user_ids')::int[])
+)
+INSERT INTO mentions (comment_id, user_id, author_id)
+SELECT sqlc.arg('comment_id'), unnest(sqlc.arg('user_ids')::int[]), sqlc.arg('author_id')
+ON CONFLICT DO NOTHING;
+
+-- name: CreateMessageMentions :exec
+INSERT INTO mentions (message_id, user_id, author_id)
+SELECT sqlc.arg('message_id'), unnest(sqlc.arg('user_ids')::int[]), sqlc.arg('author_id')
+ON CONFLICT DO NOTHING;
+
+-- name: ListMentionsByPostIDs :many
+SELECT m.post_id::int AS post_id, u.id AS user_id, u.handle
+FROM mentions m
+JOIN users u ON u.id = m.user_id
+WHERE m.post_id = ANY(sqlc.arg('post_ids')::int[]);
+
+-- name: ListMentionsByCommentIDs :many
+SELECT m.comment_id::int AS comment_id, u.id AS user_id, u.handle
+FROM mentions m
+JOIN users u ON u.id = m.user_id
+WHERE m.comment_id = ANY(sqlc.arg('comment_ids')::int[]);
+
+-- name: ListMentionsByMessageIDs :many
+SELECT m.message_id::int AS message_id, u.id AS user_id, u.handle
+FROM mentions m
+JOIN users u ON u.id = m.user_id
+WHERE m.message_id = ANY(sqlc.arg('message_ids')::int[]);
+
+-- name: ListMentionsByUserID :many
+SELECT
+    m.id,
+    m.author_id,
+    m.post_id,
+    m.comment_id,
+    m.message_id,
+    m.created_at,
+    COALESCE(p.content, c.content, msg.content)::text AS content
+FROM mentions m
+LEFT JOIN posts p ON p.id = m.post_id
+LEFT JOIN comments c ON c.id = m.comment_id
+LEFT JOIN posts cp ON cp.id = c.post_id
+LEFT JOIN messages msg ON msg.id = m.message_id
+WHERE m.user_id = sqlc.arg('user_id')
+  AND (m.post_id IS NULL OR (
+    p.status = 'published' AND p.deleted_at IS NULL
+    AND can_view_post(p.id, p.user_id, p.visibility, m.user_id)
+  ))
+  AND (m.comment_id IS NULL OR (
+    c.deleted_at IS NULL AND cp.deleted_at IS NULL
+    AND can_view_post(cp.id, cp.user_id, cp.visibility, m.user_id)
+  ))
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = m.user_id AND b.blocked_id = m.author_id)
+       OR (b.blocker_id = m.author_id AND b.blocked_id = m.user_id)
+  )
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (m.created_at, m.id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (m.created_at, m.id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN m.created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN m.id END,
+    m.created_at DESC,
+    m.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
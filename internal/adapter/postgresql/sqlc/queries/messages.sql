@@ -6,6 +6,13 @@ RETURNING id, chat_id, sender_id, content, created_at, is_read;
 -- name: ListMessagesByChatID :many
 SELECT id, chat_id, sender_id, content, created_at, is_read
 FROM messages
-WHERE chat_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE chat_id = sqlc.arg('chat_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
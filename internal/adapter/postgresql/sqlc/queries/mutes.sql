@@ -0,0 +1,20 @@
+-- name: MuteUser :one
+INSERT INTO mutes (muter_id, muted_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UnmuteUser :execrows
+DELETE FROM mutes WHERE muter_id = $1 AND muted_id = $2;
+
+-- name: ListMutedUsers :many
+SELECT * FROM mutes
+WHERE muter_id = sqlc.arg('muter_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, muted_id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, muted_id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN muted_id END,
+    created_at DESC,
+    muted_id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,15 @@
+-- name: ListNotificationsByUserID :many
+SELECT * FROM notifications
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: MarkNotificationsRead :exec
+UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL;
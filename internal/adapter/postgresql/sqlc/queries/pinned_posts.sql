@@ -0,0 +1,27 @@
+-- name: LockPinnedPosts :exec
+SELECT pg_advisory_xact_lock(hashtext('pinned_posts'), $1);
+
+-- name: PinPost :execrows
+INSERT INTO pinned_posts (user_id, post_id, position)
+SELECT sqlc.arg('user_id'), sqlc.arg('post_id'), COALESCE(MAX(position), 0) + 1
+FROM pinned_posts
+WHERE user_id = sqlc.arg('user_id')
+HAVING COUNT(*) < sqlc.arg('max_pins')::int;
+
+-- name: UnpinPost :execrows
+DELETE FROM pinned_posts WHERE user_id = $1 AND post_id = $2;
+
+-- name: ListPinnedPostsByUserID :many
+SELECT p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive FROM pinned_posts pp
+JOIN posts p ON p.id = pp.post_id
+WHERE pp.user_id = $1
+ORDER BY pp.position;
+
+-- name: ListPinnedPostIDs :many
+SELECT post_id FROM pinned_posts WHERE post_id = ANY(sqlc.arg('post_ids')::int[]);
+
+-- name: ReorderPinnedPosts :execrows
+UPDATE pinned_posts pp
+SET position = o.position
+FROM unnest(sqlc.arg('post_ids')::int[]) WITH ORDINALITY AS o(post_id, position)
+WHERE pp.post_id = o.post_id AND pp.user_id = sqlc.arg('user_id');
@@ -0,0 +1,71 @@
+-- name: CreatePoll :one
+WITH poll AS (
+    INSERT INTO polls (post_id, multiple, expires_at)
+    VALUES (sqlc.arg('post_id'), sqlc.arg('multiple'), sqlc.arg('expires_at'))
+    RETURNING id, post_id, multiple, expires_at, closed_at, created_at
+), options AS (
+    INSERT INTO poll_options (poll_id, position, text)
+    SELECT poll.id, o.position, o.text
+    FROM poll, unnest(sqlc.arg('options')::text[]) WITH ORDINALITY AS o(text, position)
+)
+SELECT id, post_id, multiple, expires_at, closed_at, created_at FROM poll;
+
+-- name: FindVisiblePollByPostID :one
+SELECT pl.* FROM polls pl
+JOIN posts p ON p.id = pl.post_id
+WHERE pl.post_id = sqlc.arg('post_id')
+  AND p.status = 'published' AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'));
+
+-- name: ListPollsByPostIDs :many
+SELECT * FROM polls WHERE post_id = ANY(sqlc.arg('post_ids')::int[]);
+
+-- name: ListPollOptionsByPollIDs :many
+SELECT o.id, o.poll_id, o.text, COUNT(v.user_id) AS votes_count
+FROM poll_options o
+LEFT JOIN poll_votes v ON v.poll_id = o.poll_id AND v.option_id = o.id
+WHERE o.poll_id = ANY(sqlc.arg('poll_ids')::int[])
+GROUP BY o.id
+ORDER BY o.poll_id, o.position;
+
+-- name: CountPollVotersByPollIDs :many
+SELECT poll_id, COUNT(DISTINCT user_id) AS count
+FROM poll_votes
+WHERE poll_id = ANY(sqlc.arg('poll_ids')::int[])
+GROUP BY poll_id;
+
+-- name: ListPollVotesByUserID :many
+SELECT poll_id, option_id
+FROM poll_votes
+WHERE poll_id = ANY(sqlc.arg('poll_ids')::int[]) AND user_id = sqlc.arg('user_id');
+
+-- name: CreatePollVotes :execrows
+INSERT INTO poll_votes (poll_id, option_id, user_id, multiple)
+SELECT pl.id, o.id, sqlc.arg('user_id'), pl.multiple
+FROM polls pl
+JOIN poll_options o ON o.poll_id = pl.id
+WHERE pl.id = sqlc.arg('poll_id')
+  AND o.id = ANY(sqlc.arg('option_ids')::int[])
+  AND pl.closed_at IS NULL AND pl.expires_at > NOW()
+  AND NOT EXISTS (
+    SELECT 1 FROM poll_votes v WHERE v.poll_id = pl.id AND v.user_id = sqlc.arg('user_id')
+  );
+
+-- name: CloseExpiredPolls :many
+WITH closed AS (
+    UPDATE polls SET closed_at = NOW()
+    WHERE id IN (
+        SELECT id FROM polls
+        WHERE closed_at IS NULL AND expires_at <= NOW()
+        ORDER BY expires_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, post_id
+), notified AS (
+    INSERT INTO notifications (user_id, type, post_id)
+    SELECT DISTINCT v.user_id, 'poll_closed', c.post_id
+    FROM closed c
+    JOIN poll_votes v ON v.poll_id = c.id
+)
+SELECT id FROM closed;
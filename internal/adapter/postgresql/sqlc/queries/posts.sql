@@ -1,23 +1,156 @@
 -- name: ListPostsByUserID :many
-SELECT * FROM posts WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3;
+SELECT p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive, k.pin_rank FROM posts p
+LEFT JOIN pinned_posts pp ON pp.post_id = p.id
+CROSS JOIN LATERAL (SELECT COALESCE(pp.position, 2147483647)::float8 AS pin_rank) k
+WHERE p.user_id = sqlc.arg('user_id')
+  AND p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (k.pin_rank > sqlc.narg('cursor_score')::float8 OR (k.pin_rank = sqlc.narg('cursor_score')::float8 AND (p.created_at, p.id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))))
+    OR (sqlc.arg('backward')::bool AND (k.pin_rank < sqlc.narg('cursor_score')::float8 OR (k.pin_rank = sqlc.narg('cursor_score')::float8 AND (p.created_at, p.id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN k.pin_rank END DESC,
+    CASE WHEN sqlc.arg('backward')::bool THEN p.created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN p.id END,
+    k.pin_rank,
+    p.created_at DESC,
+    p.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListDraftsByUserID :many
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts
+WHERE user_id = sqlc.arg('user_id') AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (updated_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (updated_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN updated_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    updated_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListTrashedPostsByUserID :many
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts
+WHERE user_id = sqlc.arg('user_id') AND deleted_at IS NOT NULL
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (deleted_at, 'post'::text, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_type')::text, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (deleted_at, 'post'::text, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_type')::text, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN deleted_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    deleted_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CreatePost :one
-INSERT INTO posts (user_id, title, content) VALUES ($1, $2, $3) RETURNING id, user_id, title, content, created_at, updated_at;
+INSERT INTO posts (user_id, title, content, status, publish_at, quote_post_id, visibility, content_warning, sensitive, language) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive;
 
 -- name: FindPostByID :one
-SELECT * FROM posts WHERE id = $1;
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: FindVisiblePostByID :one
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts
+WHERE id = sqlc.arg('id')
+  AND deleted_at IS NULL
+  AND can_view_post(id, user_id, visibility, sqlc.arg('viewer_id'));
+
+-- name: FindPostByIDIncludingDeleted :one
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts WHERE id = $1;
+
+-- name: ListPostsByIDsIncludingDeleted :many
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM posts WHERE id = ANY(sqlc.arg('ids')::int[]);
 
 -- name: UpdatePost :one
+WITH revision AS (
+    INSERT INTO post_revisions (post_id, title, content, created_at)
+    SELECT p.id, p.title, p.content, p.updated_at
+    FROM posts p
+    WHERE p.id = sqlc.arg('id')
+      AND p.status = 'published'
+      AND (p.title <> COALESCE(sqlc.narg('title'), p.title) OR p.content <> COALESCE(sqlc.narg('content'), p.content))
+), unpinned AS (
+    DELETE FROM pinned_posts
+    WHERE post_id = sqlc.arg('id') AND COALESCE(sqlc.narg('visibility'), 'public') <> 'public'
+)
 UPDATE posts 
 SET 
     title = COALESCE(sqlc.narg('title'), title),
     content = COALESCE(sqlc.narg('content'), content),
+    status = COALESCE(sqlc.narg('status'), status),
+    publish_at = COALESCE(sqlc.narg('publish_at'), publish_at),
+    visibility = COALESCE(sqlc.narg('visibility'), visibility),
+    content_warning = COALESCE(sqlc.narg('content_warning'), content_warning),
+    sensitive = COALESCE(sqlc.narg('sensitive'), sensitive),
+    edited = edited OR (status = 'published' AND (title <> COALESCE(sqlc.narg('title'), title) OR content <> COALESCE(sqlc.narg('content'), content))),
+    created_at = CASE WHEN status <> 'published' AND sqlc.narg('status') = 'published' THEN NOW() ELSE created_at END,
     updated_at = NOW()
 WHERE id = sqlc.arg('id')
-RETURNING id, user_id, title, content, created_at, updated_at;
+RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive;
+
+-- name: PublishDuePosts :many
+WITH published AS (
+    UPDATE posts
+    SET status = 'published', created_at = publish_at, updated_at = NOW()
+    WHERE id IN (
+        SELECT id FROM posts
+        WHERE status = 'scheduled' AND publish_at <= NOW() AND deleted_at IS NULL
+        ORDER BY publish_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive
+), queued AS (
+    INSERT INTO timeline_fanout (post_id)
+    SELECT id FROM published
+    ON CONFLICT (post_id) DO NOTHING
+)
+SELECT id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive FROM published;
 
 -- name: DeletePost :exec
-DELETE FROM posts WHERE id = $1;
+WITH unpinned AS (
+    DELETE FROM pinned_posts WHERE post_id = $1
+)
+UPDATE posts SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestorePost :one
+UPDATE posts SET deleted_at = NULL WHERE id = $1 RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive;
+
+-- name: PurgeDeletedPosts :execrows
+DELETE FROM posts WHERE deleted_at < $1;
 
 -- name: CountPostsByUserID :one
-SELECT COUNT(*) FROM posts WHERE user_id = $1;
+SELECT COUNT(*) FROM posts WHERE user_id = $1 AND status = 'published' AND deleted_at IS NULL;
+
+-- name: SetPostContentHTML :exec
+UPDATE posts SET content_html = $2 WHERE id = $1;
+
+-- name: SetPostFlags :one
+UPDATE posts
+SET
+    moderator_content_warning = COALESCE(sqlc.narg('moderator_content_warning'), moderator_content_warning),
+    moderator_sensitive = COALESCE(sqlc.narg('moderator_sensitive'), moderator_sensitive)
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive;
+
+-- name: SetPostCommentSettings :one
+UPDATE posts
+SET
+    comments_locked = COALESCE(sqlc.narg('comments_locked'), comments_locked),
+    comment_policy = COALESCE(sqlc.narg('comment_policy'), comment_policy)
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING id, user_id, title, content, created_at, updated_at, status, publish_at, edited, deleted_at, quote_post_id, visibility, content_html, content_warning, sensitive, language, comments_locked, comment_policy, moderator_content_warning, moderator_sensitive;
+
+-- name: CanCommentOnPost :one
+SELECT
+    p.user_id = sqlc.arg('user_id')
+    OR p.comment_policy = 'everyone'
+    OR (p.comment_policy = 'followers' AND EXISTS (
+        SELECT 1 FROM follows f WHERE f.follower_id = sqlc.arg('user_id') AND f.following_id = p.user_id
+    ))
+    OR (p.comment_policy = 'mentioned' AND EXISTS (
+        SELECT 1 FROM mentions m WHERE m.post_id = p.id AND m.user_id = sqlc.arg('user_id')
+    )) AS allowed
+FROM posts p
+WHERE p.id = sqlc.arg('id');
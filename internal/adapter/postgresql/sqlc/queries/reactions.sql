@@ -0,0 +1,67 @@
+-- name: IsPostVisible :one
+SELECT EXISTS (
+    SELECT 1 FROM posts p
+    WHERE p.id = sqlc.arg('post_id')
+      AND p.status = 'published' AND p.deleted_at IS NULL
+      AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+);
+
+-- name: IsCommentVisible :one
+SELECT EXISTS (
+    SELECT 1 FROM comments c
+    JOIN posts p ON p.id = c.post_id
+    WHERE c.id = sqlc.arg('comment_id') AND c.deleted_at IS NULL
+      AND p.status = 'published' AND p.deleted_at IS NULL
+      AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+      AND (c.hidden_at IS NULL OR c.user_id = sqlc.arg('viewer_id') OR p.user_id = sqlc.arg('viewer_id'))
+);
+
+-- name: ReactToPost :one
+INSERT INTO likes (user_id, post_id, reaction) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, post_id) DO UPDATE SET reaction = EXCLUDED.reaction
+RETURNING id, user_id, post_id, created_at, reaction;
+
+-- name: RemovePostReaction :exec
+DELETE FROM likes WHERE user_id = $1 AND post_id = $2;
+
+-- name: ListPostReactions :many
+SELECT * FROM likes
+WHERE post_id = sqlc.arg('post_id')
+  AND (sqlc.narg('reaction')::text IS NULL OR reaction = sqlc.narg('reaction'))
+  AND (sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (created_at, id) < (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (created_at, id) > (sqlc.narg('cursor_time')::timestamptz, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN created_at END,
+    CASE WHEN sqlc.arg('backward')::bool THEN id END,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountReactionsByPostIDs :many
+SELECT post_id, reaction, COUNT(*) AS count
+FROM likes
+WHERE post_id = ANY(sqlc.arg('post_ids')::int[])
+GROUP BY post_id, reaction;
+
+-- name: ListViewerPostReactions :many
+SELECT post_id, reaction FROM likes
+WHERE user_id = $1 AND post_id = ANY(sqlc.arg('post_ids')::int[]);
+
+-- name: ReactToComment :one
+INSERT INTO comment_likes (user_id, comment_id, reaction) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, comment_id) DO UPDATE SET reaction = EXCLUDED.reaction
+RETURNING id, user_id, comment_id, created_at, reaction;
+
+-- name: RemoveCommentReaction :exec
+DELETE FROM comment_likes WHERE user_id = $1 AND comment_id = $2;
+
+-- name: CountReactionsByCommentIDs :many
+SELECT comment_id, reaction, COUNT(*) AS count
+FROM comment_likes
+WHERE comment_id = ANY(sqlc.arg('comment_ids')::int[])
+GROUP BY comment_id, reaction;
+
+-- name: ListViewerCommentReactions :many
+SELECT comment_id, reaction FROM comment_likes
+WHERE user_id = $1 AND comment_id = ANY(sqlc.arg('comment_ids')::int[]);
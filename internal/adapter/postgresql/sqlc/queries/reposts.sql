@@ -0,0 +1,11 @@
+-- name: RepostPost :one
+INSERT INTO reposts (user_id, post_id) VALUES ($1, $2) RETURNING id, user_id, post_id, created_at;
+
+-- name: UndoRepost :execrows
+DELETE FROM reposts WHERE user_id = $1 AND post_id = $2;
+
+-- name: CountRepostsByPostIDs :many
+SELECT post_id, COUNT(*) AS count
+FROM reposts
+WHERE post_id = ANY(sqlc.arg('post_ids')::int[])
+GROUP BY post_id;
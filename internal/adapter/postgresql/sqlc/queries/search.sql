@@ -0,0 +1,112 @@
+-- name: SearchPosts :many
+WITH q AS (
+    SELECT to_tsquery(sqlc.arg('language')::regconfig, sqlc.arg('query')) AS query
+)
+SELECT
+    p.id, p.user_id, p.title, p.content, p.created_at, p.updated_at, p.status, p.publish_at, p.edited, p.deleted_at, p.quote_post_id, p.visibility, p.content_html, p.content_warning, p.sensitive, p.language, p.comments_locked, p.comment_policy, p.moderator_content_warning, p.moderator_sensitive,
+    ts_headline(
+        sqlc.arg('language')::regconfig,
+        replace(replace(replace(p.content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+        q.query,
+        'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "'
+    )::text AS snippet,
+    s.score
+FROM posts p
+CROSS JOIN q
+CROSS JOIN LATERAL (
+    SELECT (ts_rank_cd(p.search_vector, q.query, 32) + 0.5 * power(0.5, EXTRACT(EPOCH FROM sqlc.arg('as_of')::timestamptz - p.created_at) / 604800))::float8 AS score
+) s
+WHERE p.search_vector @@ q.query
+  AND p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = sqlc.arg('viewer_id') AND b.blocked_id = p.user_id)
+       OR (b.blocker_id = p.user_id AND b.blocked_id = sqlc.arg('viewer_id'))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes m WHERE m.muter_id = sqlc.arg('viewer_id') AND m.muted_id = p.user_id
+  )
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (s.score, p.id) < (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (s.score, p.id) > (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN s.score END,
+    CASE WHEN sqlc.arg('backward')::bool THEN p.id END,
+    s.score DESC,
+    p.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: SearchComments :many
+WITH q AS (
+    SELECT to_tsquery(sqlc.arg('language')::regconfig, sqlc.arg('query')) AS query
+)
+SELECT
+    c.id, c.post_id, c.user_id, c.content, c.created_at, c.updated_at, c.edited, c.deleted_at, c.content_html, c.language, c.parent_id, c.depth, c.hidden_at, c.deleted_by,
+    ts_headline(
+        sqlc.arg('language')::regconfig,
+        replace(replace(replace(c.content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+        q.query,
+        'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "'
+    )::text AS snippet,
+    s.score
+FROM comments c
+JOIN posts p ON p.id = c.post_id
+CROSS JOIN q
+CROSS JOIN LATERAL (
+    SELECT (ts_rank_cd(c.search_vector, q.query, 32) + 0.5 * power(0.5, EXTRACT(EPOCH FROM sqlc.arg('as_of')::timestamptz - c.created_at) / 604800))::float8 AS score
+) s
+WHERE c.search_vector @@ q.query
+  AND c.deleted_at IS NULL
+  AND c.hidden_at IS NULL
+  AND p.status = 'published'
+  AND p.deleted_at IS NULL
+  AND can_view_post(p.id, p.user_id, p.visibility, sqlc.arg('viewer_id'))
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = sqlc.arg('viewer_id') AND b.blocked_id IN (c.user_id, p.user_id))
+       OR (b.blocker_id IN (c.user_id, p.user_id) AND b.blocked_id = sqlc.arg('viewer_id'))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes m WHERE m.muter_id = sqlc.arg('viewer_id') AND m.muted_id = c.user_id
+  )
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (s.score, c.id) < (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (s.score, c.id) > (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN s.score END,
+    CASE WHEN sqlc.arg('backward')::bool THEN c.id END,
+    s.score DESC,
+    c.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: SearchUsers :many
+WITH q AS (
+    SELECT to_tsquery('simple', sqlc.arg('query')) AS query
+)
+SELECT
+    u.id,
+    u.name,
+    u.handle,
+    s.score
+FROM users u
+CROSS JOIN q
+CROSS JOIN LATERAL (
+    SELECT ts_rank_cd(to_tsvector('simple', u.name || ' ' || u.handle), q.query, 32)::float8 AS score
+) s
+WHERE to_tsvector('simple', u.name || ' ' || u.handle) @@ q.query
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_id = sqlc.arg('viewer_id') AND b.blocked_id = u.id)
+       OR (b.blocker_id = u.id AND b.blocked_id = sqlc.arg('viewer_id'))
+  )
+  AND (sqlc.narg('cursor_score')::float8 IS NULL
+    OR (NOT sqlc.arg('backward')::bool AND (s.score, u.id) < (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int))
+    OR (sqlc.arg('backward')::bool AND (s.score, u.id) > (sqlc.narg('cursor_score')::float8, sqlc.arg('cursor_id')::int)))
+ORDER BY
+    CASE WHEN sqlc.arg('backward')::bool THEN s.score END,
+    CASE WHEN sqlc.arg('backward')::bool THEN u.id END,
+    s.score DESC,
+    u.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
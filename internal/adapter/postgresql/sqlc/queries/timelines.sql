@@ -0,0 +1,36 @@
+-- name: QueueTimelineFanout :exec
+INSERT INTO timeline_fanout (post_id)
+SELECT unnest(sqlc.arg('post_ids')::int[])
+ON CONFLICT (post_id) DO NOTHING;
+
+-- name: ClaimTimelineFanout :many
+UPDATE timeline_fanout q
+SET claimed_at = NOW()
+FROM posts p
+JOIN users u ON u.id = p.user_id
+WHERE q.post_id IN (
+    SELECT post_id FROM timeline_fanout
+    WHERE claimed_at IS NULL OR claimed_at < sqlc.arg('claim_expired_before')
+    ORDER BY created_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+  AND p.id = q.post_id
+RETURNING q.post_id, p.user_id AS author_id, u.fanout_on_read;
+
+-- name: FanOutPost :exec
+INSERT INTO timelines (user_id, post_id, author_id, created_at)
+SELECT f.follower_id, p.id, p.user_id, COALESCE(p.publish_at, p.created_at)
+FROM posts p
+JOIN follows f ON f.following_id = p.user_id
+WHERE p.id = $1 AND p.status = 'published' AND p.deleted_at IS NULL
+ON CONFLICT (user_id, post_id) DO NOTHING;
+
+-- name: DeleteTimelineFanout :exec
+DELETE FROM timeline_fanout WHERE post_id = $1;
+
+-- name: SetUserFanoutOnRead :exec
+UPDATE users SET fanout_on_read = TRUE WHERE id = $1;
+
+-- name: DeleteExpiredTimelineEntries :execrows
+DELETE FROM timelines WHERE created_at < $1;
@@ -1,11 +1,14 @@
 -- name: ListUsers :many
-SELECT id, name, email, created_at, updated_at FROM users;
+SELECT id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at FROM users;
 
 -- name: FindUserByID :one
-SELECT id, name, email, created_at, updated_at FROM users WHERE id = $1;
+SELECT id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at FROM users WHERE id = $1;
 
 -- name: CreateUser :one
-INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, name, email, created_at, updated_at;
+INSERT INTO users (name, email, password, handle) VALUES ($1, $2, $3, $4) RETURNING id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at;
+
+-- name: HandleExists :one
+SELECT EXISTS (SELECT 1 FROM users WHERE handle = $1);
 
 -- name: FindUserByEmail :one
 SELECT * FROM users WHERE email = $1;
@@ -16,6 +19,23 @@ SET
     name = COALESCE(sqlc.narg('name'), name),
     email = COALESCE(sqlc.narg('email'), email),
     password = COALESCE(sqlc.narg('password'), password),
+    handle = COALESCE(sqlc.narg('handle'), handle),
+    mention_policy = COALESCE(sqlc.narg('mention_policy'), mention_policy),
+    sensitive_content = COALESCE(sqlc.narg('sensitive_content'), sensitive_content),
+    hide_likes = COALESCE(sqlc.narg('hide_likes'), hide_likes),
+    feed_ranking = COALESCE(sqlc.narg('feed_ranking'), feed_ranking),
     updated_at = NOW()
 WHERE id = sqlc.arg('id')
-RETURNING id, name, email, created_at, updated_at;
+RETURNING id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at;
+
+-- name: FindUserRoleByID :one
+SELECT role FROM users WHERE id = $1;
+
+-- name: FindUserSensitiveContentByID :one
+SELECT sensitive_content FROM users WHERE id = $1;
+
+-- name: FindUserHideLikesByID :one
+SELECT hide_likes FROM users WHERE id = $1;
+
+-- name: FindUserFeedRankingByID :one
+SELECT feed_ranking FROM users WHERE id = $1;
@@ -12,30 +12,46 @@ import (
 )
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, name, email, created_at, updated_at
+INSERT INTO users (name, email, password, handle) VALUES ($1, $2, $3, $4) RETURNING id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at
 `
 
 type CreateUserParams struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Handle   string `json:"handle"`
 }
 
 type CreateUserRow struct {
-	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	Email     string             `json:"email"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               int32              `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Handle           string             `json:"handle"`
+	MentionPolicy    string             `json:"mention_policy"`
+	SensitiveContent string             `json:"sensitive_content"`
+	HideLikes        bool               `json:"hide_likes"`
+	FeedRanking      string             `json:"feed_ranking"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Name, arg.Email, arg.Password)
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Name,
+		arg.Email,
+		arg.Password,
+		arg.Handle,
+	)
 	var i CreateUserRow
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Email,
+		&i.Handle,
+		&i.MentionPolicy,
+		&i.SensitiveContent,
+		&i.HideLikes,
+		&i.FeedRanking,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -43,7 +59,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 }
 
 const findUserByEmail = `-- name: FindUserByEmail :one
-SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1
+SELECT id, name, email, password, created_at, updated_at, role, handle, mention_policy, sensitive_content, hide_likes, fanout_on_read, feed_ranking FROM users WHERE email = $1
 `
 
 func (q *Queries) FindUserByEmail(ctx context.Context, email string) (User, error) {
@@ -56,20 +72,32 @@ func (q *Queries) FindUserByEmail(ctx context.Context, email string) (User, erro
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.Handle,
+		&i.MentionPolicy,
+		&i.SensitiveContent,
+		&i.HideLikes,
+		&i.FanoutOnRead,
+		&i.FeedRanking,
 	)
 	return i, err
 }
 
 const findUserByID = `-- name: FindUserByID :one
-SELECT id, name, email, created_at, updated_at FROM users WHERE id = $1
+SELECT id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at FROM users WHERE id = $1
 `
 
 type FindUserByIDRow struct {
-	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	Email     string             `json:"email"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               int32              `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Handle           string             `json:"handle"`
+	MentionPolicy    string             `json:"mention_policy"`
+	SensitiveContent string             `json:"sensitive_content"`
+	HideLikes        bool               `json:"hide_likes"`
+	FeedRanking      string             `json:"feed_ranking"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) FindUserByID(ctx context.Context, id int32) (FindUserByIDRow, error) {
@@ -79,22 +107,87 @@ func (q *Queries) FindUserByID(ctx context.Context, id int32) (FindUserByIDRow,
 		&i.ID,
 		&i.Name,
 		&i.Email,
+		&i.Handle,
+		&i.MentionPolicy,
+		&i.SensitiveContent,
+		&i.HideLikes,
+		&i.FeedRanking,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const findUserFeedRankingByID = `-- name: FindUserFeedRankingByID :one
+SELECT feed_ranking FROM users WHERE id = $1
+`
+
+func (q *Queries) FindUserFeedRankingByID(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRow(ctx, findUserFeedRankingByID, id)
+	var feedRanking string
+	err := row.Scan(&feedRanking)
+	return feedRanking, err
+}
+
+const findUserHideLikesByID = `-- name: FindUserHideLikesByID :one
+SELECT hide_likes FROM users WHERE id = $1
+`
+
+func (q *Queries) FindUserHideLikesByID(ctx context.Context, id int32) (bool, error) {
+	row := q.db.QueryRow(ctx, findUserHideLikesByID, id)
+	var hideLikes bool
+	err := row.Scan(&hideLikes)
+	return hideLikes, err
+}
+
+const findUserRoleByID = `-- name: FindUserRoleByID :one
+SELECT role FROM users WHERE id = $1
+`
+
+func (q *Queries) FindUserRoleByID(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRow(ctx, findUserRoleByID, id)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const findUserSensitiveContentByID = `-- name: FindUserSensitiveContentByID :one
+SELECT sensitive_content FROM users WHERE id = $1
+`
+
+func (q *Queries) FindUserSensitiveContentByID(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRow(ctx, findUserSensitiveContentByID, id)
+	var sensitiveContent string
+	err := row.Scan(&sensitiveContent)
+	return sensitiveContent, err
+}
+
+const handleExists = `-- name: HandleExists :one
+SELECT EXISTS (SELECT 1 FROM users WHERE handle = $1)
+`
+
+func (q *Queries) HandleExists(ctx context.Context, handle string) (bool, error) {
+	row := q.db.QueryRow(ctx, handleExists, handle)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT id, name, email, created_at, updated_at FROM users
+SELECT id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at FROM users
 `
 
 type ListUsersRow struct {
-	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	Email     string             `json:"email"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               int32              `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Handle           string             `json:"handle"`
+	MentionPolicy    string             `json:"mention_policy"`
+	SensitiveContent string             `json:"sensitive_content"`
+	HideLikes        bool               `json:"hide_likes"`
+	FeedRanking      string             `json:"feed_ranking"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
@@ -110,6 +203,11 @@ func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
 			&i.ID,
 			&i.Name,
 			&i.Email,
+			&i.Handle,
+			&i.MentionPolicy,
+			&i.SensitiveContent,
+			&i.HideLikes,
+			&i.FeedRanking,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -129,24 +227,39 @@ SET
     name = COALESCE($1, name),
     email = COALESCE($2, email),
     password = COALESCE($3, password),
+    handle = COALESCE($4, handle),
+    mention_policy = COALESCE($5, mention_policy),
+    sensitive_content = COALESCE($6, sensitive_content),
+    hide_likes = COALESCE($7, hide_likes),
+    feed_ranking = COALESCE($8, feed_ranking),
     updated_at = NOW()
-WHERE id = $4
-RETURNING id, name, email, created_at, updated_at
+WHERE id = $9
+RETURNING id, name, email, handle, mention_policy, sensitive_content, hide_likes, feed_ranking, created_at, updated_at
 `
 
 type UpdateUserParams struct {
-	Name     pgtype.Text `json:"name"`
-	Email    pgtype.Text `json:"email"`
-	Password pgtype.Text `json:"password"`
-	ID       int32       `json:"id"`
+	Name             pgtype.Text `json:"name"`
+	Email            pgtype.Text `json:"email"`
+	Password         pgtype.Text `json:"password"`
+	Handle           pgtype.Text `json:"handle"`
+	MentionPolicy    pgtype.Text `json:"mention_policy"`
+	SensitiveContent pgtype.Text `json:"sensitive_content"`
+	HideLikes        pgtype.Bool `json:"hide_likes"`
+	FeedRanking      pgtype.Text `json:"feed_ranking"`
+	ID               int32       `json:"id"`
 }
 
 type UpdateUserRow struct {
-	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	Email     string             `json:"email"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               int32              `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Handle           string             `json:"handle"`
+	MentionPolicy    string             `json:"mention_policy"`
+	SensitiveContent string             `json:"sensitive_content"`
+	HideLikes        bool               `json:"hide_likes"`
+	FeedRanking      string             `json:"feed_ranking"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error) {
@@ -154,6 +267,11 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 		arg.Name,
 		arg.Email,
 		arg.Password,
+		arg.Handle,
+		arg.MentionPolicy,
+		arg.SensitiveContent,
+		arg.HideLikes,
+		arg.FeedRanking,
 		arg.ID,
 	)
 	var i UpdateUserRow
@@ -161,6 +279,11 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 		&i.ID,
 		&i.Name,
 		&i.Email,
+		&i.Handle,
+		&i.MentionPolicy,
+		&i.SensitiveContent,
+		&i.HideLikes,
+		&i.FeedRanking,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
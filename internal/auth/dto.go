@@ -1,7 +1,10 @@
 package auth
 
+// RegisterRequest.Handle is optional. Without one, a handle is derived from
+// the email address.
 type RegisterRequest struct {
 	Name     string `json:"name"`
+	Handle   string `json:"handle"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
@@ -2,10 +2,12 @@ package auth
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/validator"
 )
 
 const refreshTokenCookieName = "refresh_token"
@@ -55,8 +57,13 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case ErrUserAlreadyExists:
 			http.Error(w, "user already exists", http.StatusConflict)
+		case validator.ErrEmailEmpty, validator.ErrEmailInvalid,
+			validator.ErrPasswordEmpty, validator.ErrPasswordTooShort, validator.ErrPasswordTooLong,
+			validator.ErrHandleEmpty, validator.ErrHandleInvalid:
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		default:
-			http.Error(w, "failed to register", http.StatusBadRequest)
+			slog.Error("failed to register", "error", err)
+			http.Error(w, "failed to register", http.StatusInternalServerError)
 		}
 		return
 	}
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth/v5"
 )
@@ -12,3 +15,46 @@ func RequireAuth(h *Handler) func(chi.Router) {
 		r.Use(ExtractUserID)
 	}
 }
+
+// OptionalAuth identifies the caller when an access token is sent and lets
+// anonymous requests through, for which UserIDFromContext returns 0. An
+// invalid or expired token is still rejected.
+func OptionalAuth(h *Handler) func(chi.Router) {
+	return func(r chi.Router) {
+		r.Use(jwtauth.Verifier(h.jwtAuth.GetTokenAuth()))
+		r.Use(func(next http.Handler) http.Handler {
+			authenticated := ExtractUserID(next)
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				token, _, err := jwtauth.FromContext(r.Context())
+				if errors.Is(err, jwtauth.ErrNoTokenFound) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				if err != nil || token == nil {
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				authenticated.ServeHTTP(w, r)
+			})
+		})
+	}
+}
+
+// RequireModerator must be mounted after RequireAuth. The role is looked up on
+// every request so that demoting a moderator takes effect immediately.
+func RequireModerator(h *Handler) func(chi.Router) {
+	return func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				role, err := h.service.FindUserRole(r.Context(), UserIDFromContext(r.Context()))
+				if err != nil || role != RoleModerator {
+					http.Error(w, "forbidden", http.StatusForbidden)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			})
+		})
+	}
+}
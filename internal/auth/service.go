@@ -3,6 +3,10 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
 	"github.com/etherealsense/social-network/pkg/crypto"
@@ -10,6 +14,15 @@ import (
 	"github.com/etherealsense/social-network/pkg/validator"
 )
 
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+)
+
+const defaultHandleAttempts = 5
+
+var nonHandleChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
 var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrUserNotFound       = errors.New("user not found")
@@ -19,6 +32,7 @@ var (
 type Service interface {
 	Register(ctx context.Context, req RegisterRequest) (repo.CreateUserRow, error)
 	Login(ctx context.Context, req LoginRequest) (repo.CreateUserRow, error)
+	FindUserRole(ctx context.Context, userID int32) (string, error)
 }
 
 type svc struct {
@@ -40,6 +54,19 @@ func (s *svc) Register(ctx context.Context, req RegisterRequest) (repo.CreateUse
 		return repo.CreateUserRow{}, err
 	}
 
+	if req.Handle == "" {
+		req.Handle, err = s.defaultHandle(ctx, req.Email)
+		if err != nil {
+			return repo.CreateUserRow{}, err
+		}
+	}
+
+	req.Handle = strings.ToLower(req.Handle)
+	err = validator.ValidateHandle(req.Handle)
+	if err != nil {
+		return repo.CreateUserRow{}, err
+	}
+
 	hashedPassword, err := crypto.HashPassword(req.Password)
 	if err != nil {
 		return repo.CreateUserRow{}, err
@@ -49,6 +76,7 @@ func (s *svc) Register(ctx context.Context, req RegisterRequest) (repo.CreateUse
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: hashedPassword,
+		Handle:   req.Handle,
 	})
 	if err != nil {
 		if database.IsUniqueViolation(err) {
@@ -61,6 +89,34 @@ func (s *svc) Register(ctx context.Context, req RegisterRequest) (repo.CreateUse
 	return user, nil
 }
 
+// defaultHandle derives a free handle from the local part of email, for
+// clients that register without one. A random suffix is added when the
+// handle is taken.
+func (s *svc) defaultHandle(ctx context.Context, email string) (string, error) {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	base := strings.Trim(nonHandleChars.ReplaceAllString(local, "_"), "_")
+	if len(base) < 3 {
+		base = "user_" + base
+	}
+	base = base[:min(len(base), 24)]
+
+	for i := range defaultHandleAttempts {
+		handle := base
+		if i > 0 {
+			handle = fmt.Sprintf("%s_%d", base, rand.IntN(100000))
+		}
+
+		taken, err := s.repo.HandleExists(ctx, handle)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return handle, nil
+		}
+	}
+	return "", ErrUserAlreadyExists
+}
+
 func (s *svc) Login(ctx context.Context, req LoginRequest) (repo.CreateUserRow, error) {
 	user, err := s.repo.FindUserByEmail(ctx, req.Email)
 	if err != nil {
@@ -73,10 +129,23 @@ func (s *svc) Login(ctx context.Context, req LoginRequest) (repo.CreateUserRow,
 	}
 
 	return repo.CreateUserRow{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Handle:           user.Handle,
+		MentionPolicy:    user.MentionPolicy,
+		SensitiveContent: user.SensitiveContent,
+		HideLikes:        user.HideLikes,
+		FeedRanking:      user.FeedRanking,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}, nil
 }
+
+func (s *svc) FindUserRole(ctx context.Context, userID int32) (string, error) {
+	role, err := s.repo.FindUserRoleByID(ctx, userID)
+	if err != nil {
+		return "", ErrUserNotFound
+	}
+	return role, nil
+}
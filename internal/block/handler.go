@@ -0,0 +1,92 @@
+package block
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	blockedIDStr := chi.URLParam(r, "user_id")
+	blockedID, err := strconv.Atoi(blockedIDStr)
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	b, err := h.service.BlockUser(r.Context(), uid, int32(blockedID))
+	if err != nil {
+		switch err {
+		case ErrSelfBlock:
+			http.Error(w, "cannot block yourself", http.StatusBadRequest)
+		case ErrUserNotFound:
+			http.Error(w, "user not found", http.StatusNotFound)
+		case ErrAlreadyBlocked:
+			http.Error(w, "already blocked this user", http.StatusConflict)
+		default:
+			slog.Error("failed to block user", "error", err)
+			http.Error(w, "failed to block user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, b)
+}
+
+func (h *Handler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	blockedIDStr := chi.URLParam(r, "user_id")
+	blockedID, err := strconv.Atoi(blockedIDStr)
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.UnblockUser(r.Context(), uid, int32(blockedID))
+	if err != nil {
+		switch err {
+		case ErrNotBlocked:
+			http.Error(w, "user is not blocked", http.StatusNotFound)
+		default:
+			slog.Error("failed to unblock user", "error", err)
+			http.Error(w, "failed to unblock user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListBlockedUsers(r.Context(), uid, p)
+	if err != nil {
+		slog.Error("failed to list blocked users", "error", err, "user_id", uid)
+		http.Error(w, "failed to list blocked users", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, page)
+}
@@ -0,0 +1,86 @@
+package block
+
+import (
+	"context"
+	"errors"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+var (
+	ErrAlreadyBlocked = errors.New("already blocked this user")
+	ErrNotBlocked     = errors.New("user is not blocked")
+	ErrSelfBlock      = errors.New("cannot block yourself")
+	ErrUserNotFound   = errors.New("user not found")
+)
+
+type Service interface {
+	BlockUser(ctx context.Context, blockerID, blockedID int32) (repo.Block, error)
+	UnblockUser(ctx context.Context, blockerID, blockedID int32) error
+	ListBlockedUsers(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Block], error)
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// BlockUser blocks a user and removes the follows between both users.
+func (s *svc) BlockUser(ctx context.Context, blockerID, blockedID int32) (repo.Block, error) {
+	if blockerID == blockedID {
+		return repo.Block{}, ErrSelfBlock
+	}
+
+	b, err := s.repo.BlockUser(ctx, repo.BlockUserParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	})
+	if err != nil {
+		switch {
+		case database.IsUniqueViolation(err):
+			return repo.Block{}, ErrAlreadyBlocked
+		case database.IsForeignKeyViolation(err):
+			return repo.Block{}, ErrUserNotFound
+		}
+		return repo.Block{}, err
+	}
+	return b, nil
+}
+
+func (s *svc) UnblockUser(ctx context.Context, blockerID, blockedID int32) error {
+	n, err := s.repo.UnblockUser(ctx, repo.UnblockUserParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotBlocked
+	}
+	return nil
+}
+
+func (s *svc) ListBlockedUsers(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Block], error) {
+	blocks, err := s.repo.ListBlockedUsers(ctx, repo.ListBlockedUsersParams{
+		BlockerID:  userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Block]{}, err
+	}
+
+	blocks, c := pagination.Paginate(blocks, p, func(b repo.Block) pagination.Key {
+		return pagination.Key{Time: b.CreatedAt.Time, ID: b.BlockedID}
+	})
+	return pagination.NewPage(blocks, c), nil
+}
@@ -0,0 +1,25 @@
+package bookmark
+
+import (
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type BookmarkRequest struct {
+	CollectionID *int32 `json:"collection_id"`
+}
+
+type CollectionRequest struct {
+	Name string `json:"name"`
+}
+
+type ReorderCollectionsRequest struct {
+	IDs []int32 `json:"ids"`
+}
+
+type BookmarkResponse struct {
+	ID           int32              `json:"id"`
+	CollectionID pgtype.Int4        `json:"collection_id"`
+	BookmarkedAt pgtype.Timestamptz `json:"bookmarked_at"`
+	Post         post.PostResponse  `json:"post"`
+}
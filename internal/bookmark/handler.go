@@ -0,0 +1,242 @@
+package bookmark
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) BookmarkPost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	var req BookmarkRequest
+	if r.ContentLength != 0 {
+		if err := json.Read(r, &req); err != nil {
+			slog.Error("failed to read bookmark request", "error", err)
+			http.Error(w, "failed to read bookmark request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	b, err := h.service.BookmarkPost(r.Context(), uid, int32(postID), req)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrCollectionNotFound:
+			http.Error(w, "collection not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to bookmark post", "error", err, "post_id", postID, "user_id", uid)
+			http.Error(w, "failed to bookmark post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, b)
+}
+
+func (h *Handler) DeleteBookmark(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.DeleteBookmark(r.Context(), uid, int32(postID))
+	if err != nil {
+		switch err {
+		case ErrBookmarkNotFound:
+			http.Error(w, "bookmark not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to delete bookmark", "error", err, "post_id", postID, "user_id", uid)
+			http.Error(w, "failed to delete bookmark", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListBookmarks(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	var collectionID pgtype.Int4
+	if idStr := r.URL.Query().Get("collection_id"); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "invalid collection_id", http.StatusBadRequest)
+			return
+		}
+		collectionID = pgtype.Int4{Int32: int32(id), Valid: true}
+	}
+
+	page, err := h.service.ListBookmarks(r.Context(), uid, collectionID, p)
+	if err != nil {
+		switch err {
+		case ErrCollectionNotFound:
+			http.Error(w, "collection not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to list bookmarks", "error", err, "user_id", uid)
+			http.Error(w, "failed to list bookmarks", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, page)
+}
+
+func (h *Handler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	var req CollectionRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read create collection request", "error", err)
+		http.Error(w, "failed to read create collection request", http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.service.CreateCollection(r.Context(), uid, req)
+	if err != nil {
+		switch err {
+		case ErrInvalidCollectionName, ErrTooManyCollections:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrCollectionExists:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			slog.Error("failed to create collection", "error", err, "user_id", uid)
+			http.Error(w, "failed to create collection", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, c)
+}
+
+func (h *Handler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	collections, err := h.service.ListCollections(r.Context(), uid)
+	if err != nil {
+		slog.Error("failed to list collections", "error", err, "user_id", uid)
+		http.Error(w, "failed to list collections", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, collections)
+}
+
+func (h *Handler) RenameCollection(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid collection id", http.StatusBadRequest)
+		return
+	}
+
+	var req CollectionRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read rename collection request", "error", err)
+		http.Error(w, "failed to read rename collection request", http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.service.RenameCollection(r.Context(), int32(id), uid, req)
+	if err != nil {
+		switch err {
+		case ErrCollectionNotFound:
+			http.Error(w, "collection not found", http.StatusNotFound)
+		case ErrInvalidCollectionName:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrCollectionExists:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			slog.Error("failed to rename collection", "error", err, "collection_id", id, "user_id", uid)
+			http.Error(w, "failed to rename collection", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, c)
+}
+
+func (h *Handler) ReorderCollections(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	var req ReorderCollectionsRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read reorder collections request", "error", err)
+		http.Error(w, "failed to read reorder collections request", http.StatusBadRequest)
+		return
+	}
+
+	collections, err := h.service.ReorderCollections(r.Context(), uid, req)
+	if err != nil {
+		switch err {
+		case ErrInvalidOrder:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to reorder collections", "error", err, "user_id", uid)
+			http.Error(w, "failed to reorder collections", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, collections)
+}
+
+func (h *Handler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid collection id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.DeleteCollection(r.Context(), int32(id), uid)
+	if err != nil {
+		switch err {
+		case ErrCollectionNotFound:
+			http.Error(w, "collection not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to delete collection", "error", err, "collection_id", id, "user_id", uid)
+			http.Error(w, "failed to delete collection", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,254 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	MaxCollections          = 100
+	MaxCollectionNameLength = 100
+)
+
+var (
+	ErrPostNotFound          = errors.New("post not found")
+	ErrBookmarkNotFound      = errors.New("bookmark not found")
+	ErrCollectionNotFound    = errors.New("collection not found")
+	ErrCollectionExists      = errors.New("collection already exists")
+	ErrInvalidCollectionName = errors.New("collection name must be 1 to 100 characters")
+	ErrTooManyCollections    = errors.New("too many collections")
+	ErrInvalidOrder          = errors.New("order must list every collection exactly once")
+)
+
+type Service interface {
+	BookmarkPost(ctx context.Context, userID, postID int32, req BookmarkRequest) (repo.Bookmark, error)
+	DeleteBookmark(ctx context.Context, userID, postID int32) error
+	ListBookmarks(ctx context.Context, userID int32, collectionID pgtype.Int4, p pagination.Params) (pagination.Page[BookmarkResponse], error)
+	CreateCollection(ctx context.Context, userID int32, req CollectionRequest) (repo.BookmarkCollection, error)
+	ListCollections(ctx context.Context, userID int32) ([]repo.BookmarkCollection, error)
+	RenameCollection(ctx context.Context, id, userID int32, req CollectionRequest) (repo.BookmarkCollection, error)
+	ReorderCollections(ctx context.Context, userID int32, req ReorderCollectionsRequest) ([]repo.BookmarkCollection, error)
+	DeleteCollection(ctx context.Context, id, userID int32) error
+}
+
+type svc struct {
+	repo  repo.Querier
+	posts post.Service
+}
+
+func NewService(repo repo.Querier, posts post.Service) Service {
+	return &svc{repo: repo, posts: posts}
+}
+
+// BookmarkPost saves a post for userID, or moves an existing bookmark to
+// another collection. A nil collection keeps it in the default list.
+func (s *svc) BookmarkPost(ctx context.Context, userID, postID int32, req BookmarkRequest) (repo.Bookmark, error) {
+	p, err := s.repo.FindVisiblePostByID(ctx, repo.FindVisiblePostByIDParams{
+		ID:       postID,
+		ViewerID: userID,
+	})
+	if err != nil || p.Status != post.StatusPublished {
+		return repo.Bookmark{}, ErrPostNotFound
+	}
+
+	var collectionID pgtype.Int4
+	if req.CollectionID != nil {
+		if _, err := s.findCollection(ctx, *req.CollectionID, userID); err != nil {
+			return repo.Bookmark{}, err
+		}
+		collectionID = pgtype.Int4{Int32: *req.CollectionID, Valid: true}
+	}
+
+	return s.repo.BookmarkPost(ctx, repo.BookmarkPostParams{
+		UserID:       userID,
+		PostID:       postID,
+		CollectionID: collectionID,
+	})
+}
+
+func (s *svc) DeleteBookmark(ctx context.Context, userID, postID int32) error {
+	n, err := s.repo.DeleteBookmark(ctx, repo.DeleteBookmarkParams{
+		UserID: userID,
+		PostID: postID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// ListBookmarks returns the bookmarks of userID, newest first, optionally
+// limited to one collection. Bookmarks of posts that were deleted or are no
+// longer visible to the user are skipped.
+func (s *svc) ListBookmarks(ctx context.Context, userID int32, collectionID pgtype.Int4, p pagination.Params) (pagination.Page[BookmarkResponse], error) {
+	if collectionID.Valid {
+		if _, err := s.findCollection(ctx, collectionID.Int32, userID); err != nil {
+			return pagination.Page[BookmarkResponse]{}, err
+		}
+	}
+
+	rows, err := s.repo.ListBookmarksByUserID(ctx, repo.ListBookmarksByUserIDParams{
+		UserID:       userID,
+		CollectionID: collectionID,
+		CursorID:     p.CursorID(),
+		Backward:     p.Backward(),
+		Limit:        p.Fetch(),
+		Offset:       p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[BookmarkResponse]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.ListBookmarksByUserIDRow) pagination.Key {
+		return pagination.Key{ID: r.BookmarkID}
+	})
+
+	posts := make([]repo.Post, len(rows))
+	for i, row := range rows {
+		posts[i] = row.Post
+	}
+
+	hydrated, err := s.posts.ToResponses(ctx, posts, userID)
+	if err != nil {
+		return pagination.Page[BookmarkResponse]{}, err
+	}
+
+	bookmarks := make([]BookmarkResponse, len(rows))
+	for i, row := range rows {
+		bookmarks[i] = BookmarkResponse{
+			ID:           row.BookmarkID,
+			CollectionID: row.CollectionID,
+			BookmarkedAt: row.BookmarkedAt,
+			Post:         hydrated[i],
+		}
+	}
+	return pagination.NewPage(bookmarks, c), nil
+}
+
+func (s *svc) CreateCollection(ctx context.Context, userID int32, req CollectionRequest) (repo.BookmarkCollection, error) {
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return repo.BookmarkCollection{}, err
+	}
+
+	count, err := s.repo.CountBookmarkCollectionsByUserID(ctx, userID)
+	if err != nil {
+		return repo.BookmarkCollection{}, err
+	}
+
+	if count >= MaxCollections {
+		return repo.BookmarkCollection{}, ErrTooManyCollections
+	}
+
+	c, err := s.repo.CreateBookmarkCollection(ctx, repo.CreateBookmarkCollectionParams{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return repo.BookmarkCollection{}, ErrCollectionExists
+		}
+		return repo.BookmarkCollection{}, err
+	}
+	return c, nil
+}
+
+func (s *svc) ListCollections(ctx context.Context, userID int32) ([]repo.BookmarkCollection, error) {
+	return s.repo.ListBookmarkCollectionsByUserID(ctx, userID)
+}
+
+func (s *svc) RenameCollection(ctx context.Context, id, userID int32, req CollectionRequest) (repo.BookmarkCollection, error) {
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return repo.BookmarkCollection{}, err
+	}
+
+	if _, err := s.findCollection(ctx, id, userID); err != nil {
+		return repo.BookmarkCollection{}, err
+	}
+
+	c, err := s.repo.RenameBookmarkCollection(ctx, repo.RenameBookmarkCollectionParams{
+		ID:   id,
+		Name: name,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return repo.BookmarkCollection{}, ErrCollectionExists
+		}
+		return repo.BookmarkCollection{}, err
+	}
+	return c, nil
+}
+
+// ReorderCollections sets the order of all collections of userID to the
+// order of req.IDs.
+func (s *svc) ReorderCollections(ctx context.Context, userID int32, req ReorderCollectionsRequest) ([]repo.BookmarkCollection, error) {
+	collections, err := s.repo.ListBookmarkCollectionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.IDs) != len(collections) {
+		return nil, ErrInvalidOrder
+	}
+	for _, c := range collections {
+		if !slices.Contains(req.IDs, c.ID) {
+			return nil, ErrInvalidOrder
+		}
+	}
+
+	n, err := s.repo.ReorderBookmarkCollections(ctx, repo.ReorderBookmarkCollectionsParams{
+		Ids:    req.IDs,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if n != int64(len(req.IDs)) {
+		return nil, ErrInvalidOrder
+	}
+
+	return s.repo.ListBookmarkCollectionsByUserID(ctx, userID)
+}
+
+// DeleteCollection removes a collection. Its bookmarks are kept and move back
+// to the default list.
+func (s *svc) DeleteCollection(ctx context.Context, id, userID int32) error {
+	if _, err := s.findCollection(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteBookmarkCollection(ctx, id)
+}
+
+// findCollection reports collections of other users as not found, since
+// bookmarks are private.
+func (s *svc) findCollection(ctx context.Context, id, userID int32) (repo.BookmarkCollection, error) {
+	c, err := s.repo.FindBookmarkCollectionByID(ctx, id)
+	if err != nil || c.UserID != userID {
+		return repo.BookmarkCollection{}, ErrCollectionNotFound
+	}
+	return c, nil
+}
+
+func collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxCollectionNameLength {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
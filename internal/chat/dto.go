@@ -1,6 +1,9 @@
 package chat
 
-import "github.com/jackc/pgx/v5/pgtype"
+import (
+	"github.com/etherealsense/social-network/pkg/mention"
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 type CreateChatRequest struct {
 	UserID int32 `json:"user_id"`
@@ -16,4 +19,6 @@ type MessageResponse struct {
 	SenderID  int32              `json:"sender_id"`
 	Content   string             `json:"content"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	IsRead    bool               `json:"is_read"`
+	Mentions  []mention.Entity   `json:"mentions"`
 }
@@ -53,9 +53,13 @@ func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) ListChats(w http.ResponseWriter, r *http.Request) {
 	uid := auth.UserIDFromContext(r.Context())
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	chats, err := h.service.ListChatsByUserID(r.Context(), uid, p.Limit, p.Offset)
+	chats, err := h.service.ListChatsByUserID(r.Context(), uid, p)
 	if err != nil {
 		slog.Error("failed to list chats", "error", err, "user_id", uid)
 		http.Error(w, "failed to list chats", http.StatusInternalServerError)
@@ -73,9 +77,13 @@ func (h *Handler) ListParticipants(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	participants, err := h.service.ListParticipantsByChatID(r.Context(), int32(chatID), p.Limit, p.Offset)
+	participants, err := h.service.ListParticipantsByChatID(r.Context(), int32(chatID), p)
 	if err != nil {
 		slog.Error("failed to list participants", "error", err, "chat_id", chatID)
 		http.Error(w, "failed to list participants", http.StatusInternalServerError)
@@ -100,9 +108,13 @@ func (h *Handler) ListMessages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	messages, err := h.service.ListMessagesByChatID(r.Context(), int32(chatID), p.Limit, p.Offset)
+	messages, err := h.service.ListMessagesByChatID(r.Context(), int32(chatID), p)
 	if err != nil {
 		slog.Error("failed to list messages", "error", err, "chat_id", chatID)
 		http.Error(w, "failed to list messages", http.StatusInternalServerError)
@@ -170,13 +182,7 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		h.hub.Broadcast(int32(chatID), MessageResponse{
-			ID:        msg.ID,
-			ChatID:    msg.ChatID,
-			SenderID:  msg.SenderID,
-			Content:   msg.Content,
-			CreatedAt: msg.CreatedAt,
-		})
+		h.hub.Broadcast(int32(chatID), msg)
 	}
 
 	conn.Close(websocket.StatusNormalClosure, "")
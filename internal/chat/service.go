@@ -6,6 +6,8 @@ import (
 	"time"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/mention"
+	"github.com/etherealsense/social-network/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -19,10 +21,10 @@ var (
 
 type Service interface {
 	CreateChat(ctx context.Context, userID int32, req CreateChatRequest) (repo.Chat, error)
-	ListChatsByUserID(ctx context.Context, userID, limit, offset int32) ([]repo.Chat, error)
-	ListParticipantsByChatID(ctx context.Context, chatID, limit, offset int32) ([]repo.ChatParticipant, error)
-	CreateMessage(ctx context.Context, chatID, senderID int32, content string) (repo.Message, error)
-	ListMessagesByChatID(ctx context.Context, chatID, limit, offset int32) ([]repo.Message, error)
+	ListChatsByUserID(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Chat], error)
+	ListParticipantsByChatID(ctx context.Context, chatID int32, p pagination.Params) (pagination.Page[repo.ChatParticipant], error)
+	CreateMessage(ctx context.Context, chatID, senderID int32, content string) (MessageResponse, error)
+	ListMessagesByChatID(ctx context.Context, chatID int32, p pagination.Params) (pagination.Page[MessageResponse], error)
 	IsParticipant(ctx context.Context, chatID, userID int32) error
 }
 
@@ -80,40 +82,159 @@ func (s *svc) CreateChat(ctx context.Context, userID int32, req CreateChatReques
 	return chat, nil
 }
 
-func (s *svc) ListChatsByUserID(ctx context.Context, userID, limit, offset int32) ([]repo.Chat, error) {
-	return s.repo.ListChatsByUserID(ctx, repo.ListChatsByUserIDParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
+func (s *svc) ListChatsByUserID(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Chat], error) {
+	chats, err := s.repo.ListChatsByUserID(ctx, repo.ListChatsByUserIDParams{
+		UserID:     userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Chat]{}, err
+	}
+
+	chats, c := pagination.Paginate(chats, p, func(c repo.Chat) pagination.Key {
+		return pagination.Key{Time: c.CreatedAt.Time, ID: c.ID}
 	})
+	return pagination.NewPage(chats, c), nil
 }
 
-func (s *svc) ListParticipantsByChatID(ctx context.Context, chatID, limit, offset int32) ([]repo.ChatParticipant, error) {
-	return s.repo.ListChatParticipantsByChatID(ctx, repo.ListChatParticipantsByChatIDParams{
-		ChatID: chatID,
-		Limit:  limit,
-		Offset: offset,
+func (s *svc) ListParticipantsByChatID(ctx context.Context, chatID int32, p pagination.Params) (pagination.Page[repo.ChatParticipant], error) {
+	participants, err := s.repo.ListChatParticipantsByChatID(ctx, repo.ListChatParticipantsByChatIDParams{
+		ChatID:     chatID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
 	})
+	if err != nil {
+		return pagination.Page[repo.ChatParticipant]{}, err
+	}
+
+	participants, c := pagination.Paginate(participants, p, func(cp repo.ChatParticipant) pagination.Key {
+		return pagination.Key{Time: cp.JoinedAt.Time, ID: cp.UserID}
+	})
+	return pagination.NewPage(participants, c), nil
 }
 
-func (s *svc) CreateMessage(ctx context.Context, chatID, senderID int32, content string) (repo.Message, error) {
+func (s *svc) CreateMessage(ctx context.Context, chatID, senderID int32, content string) (MessageResponse, error) {
 	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
 
-	return s.repo.CreateMessage(ctx, repo.CreateMessageParams{
+	msg, err := s.repo.CreateMessage(ctx, repo.CreateMessageParams{
 		ChatID:    chatID,
 		SenderID:  senderID,
 		Content:   content,
 		CreatedAt: now,
 		IsRead:    false,
 	})
+	if err != nil {
+		return MessageResponse{}, err
+	}
+
+	if err := s.createMentions(ctx, msg); err != nil {
+		return MessageResponse{}, err
+	}
+
+	res, err := s.toResponses(ctx, []repo.Message{msg})
+	if err != nil {
+		return MessageResponse{}, err
+	}
+	return res[0], nil
 }
 
-func (s *svc) ListMessagesByChatID(ctx context.Context, chatID, limit, offset int32) ([]repo.Message, error) {
-	return s.repo.ListMessagesByChatID(ctx, repo.ListMessagesByChatIDParams{
-		ChatID: chatID,
-		Limit:  limit,
-		Offset: offset,
+func (s *svc) ListMessagesByChatID(ctx context.Context, chatID int32, p pagination.Params) (pagination.Page[MessageResponse], error) {
+	messages, err := s.repo.ListMessagesByChatID(ctx, repo.ListMessagesByChatIDParams{
+		ChatID:     chatID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
 	})
+	if err != nil {
+		return pagination.Page[MessageResponse]{}, err
+	}
+
+	messages, c := pagination.Paginate(messages, p, func(m repo.Message) pagination.Key {
+		return pagination.Key{Time: m.CreatedAt.Time, ID: m.ID}
+	})
+
+	res, err := s.toResponses(ctx, messages)
+	if err != nil {
+		return pagination.Page[MessageResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+// createMentions records the mentions in a message. Only participants of the
+// chat can be mentioned, so the message never leaks to anyone else through
+// their mentions.
+func (s *svc) createMentions(ctx context.Context, msg repo.Message) error {
+	handles := mention.Handles(msg.Content)
+	if len(handles) == 0 {
+		return nil
+	}
+
+	users, err := s.repo.ResolveMentions(ctx, repo.ResolveMentionsParams{
+		Handles:  handles,
+		AuthorID: msg.SenderID,
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int32, 0, len(users))
+	for _, u := range users {
+		if err := s.IsParticipant(ctx, msg.ChatID, u.ID); err == nil {
+			ids = append(ids, u.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.repo.CreateMessageMentions(ctx, repo.CreateMessageMentionsParams{
+		MessageID: msg.ID,
+		UserIds:   ids,
+		AuthorID:  msg.SenderID,
+	})
+}
+
+func (s *svc) toResponses(ctx context.Context, messages []repo.Message) ([]MessageResponse, error) {
+	ids := make([]int32, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+	}
+
+	rows, err := s.repo.ListMentionsByMessageIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := make(map[int32]map[string]int32)
+	for _, row := range rows {
+		if mentioned[row.MessageID] == nil {
+			mentioned[row.MessageID] = make(map[string]int32)
+		}
+		mentioned[row.MessageID][row.Handle] = row.UserID
+	}
+
+	res := make([]MessageResponse, len(messages))
+	for i, m := range messages {
+		res[i] = MessageResponse{
+			ID:        m.ID,
+			ChatID:    m.ChatID,
+			SenderID:  m.SenderID,
+			Content:   m.Content,
+			CreatedAt: m.CreatedAt,
+			IsRead:    m.IsRead,
+			Mentions:  mention.Entities(m.Content, mentioned[m.ID]),
+		}
+	}
+	return res, nil
 }
 
 func (s *svc) IsParticipant(ctx context.Context, chatID, userID int32) error {
@@ -1,9 +1,36 @@
 package comment
 
+import (
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/mention"
+)
+
+// CreateCommentRequest.Language defaults to the language of the post.
+// ParentID makes the comment a reply.
 type CreateCommentRequest struct {
-	Content string `json:"content"`
+	Content  string `json:"content"`
+	Language string `json:"language"`
+	ParentID *int32 `json:"parent_id"`
 }
 
 type UpdateCommentRequest struct {
 	Content *string `json:"content"`
 }
+
+// CommentResponse.Replies holds the replies loaded along with the comment.
+// When there are more, RepliesCursor continues after the last of them; when
+// none were loaded, replies are fetched from the start.
+type CommentResponse struct {
+	repo.Comment
+	ContentHTML string           `json:"content_html,omitempty"`
+	Mentions    []mention.Entity `json:"mentions"`
+	LikesCount  int64            `json:"likes_count"`
+	Liked       bool             `json:"liked"`
+	// Reactions counts the reactions to the comment by type. ViewerReaction
+	// is null when the viewer has not reacted.
+	Reactions      map[string]int64  `json:"reactions"`
+	ViewerReaction *string           `json:"viewer_reaction"`
+	RepliesCount   int64             `json:"replies_count"`
+	Replies        []CommentResponse `json:"replies,omitempty"`
+	RepliesCursor  string            `json:"replies_cursor,omitempty"`
+}
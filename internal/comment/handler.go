@@ -38,8 +38,19 @@ func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
 
 	comment, err := h.service.CreateComment(r.Context(), int32(postID), uid, req)
 	if err != nil {
-		slog.Error("failed to create comment", "error", err, "post_id", postID, "user_id", uid)
-		http.Error(w, "failed to create comment", http.StatusInternalServerError)
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrInvalidLanguage, ErrParentNotFound, ErrThreadTooDeep:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrCommentsLocked:
+			json.Error(w, http.StatusForbidden, "comments_locked", err.Error())
+		case ErrCommentNotAllowed:
+			json.Error(w, http.StatusForbidden, "comment_not_allowed", err.Error())
+		default:
+			slog.Error("failed to create comment", "error", err, "post_id", postID, "user_id", uid)
+			http.Error(w, "failed to create comment", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -54,7 +65,7 @@ func (h *Handler) GetComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comment, err := h.service.FindCommentByID(r.Context(), int32(id))
+	comment, err := h.service.FindCommentByID(r.Context(), int32(id), auth.UserIDFromContext(r.Context()))
 	if err != nil {
 		switch err {
 		case ErrCommentNotFound:
@@ -129,7 +140,98 @@ func (h *Handler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) ListCommentsByPostID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.RestoreComment(r.Context(), int32(id), uid)
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		case ErrCommentForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case ErrCommentNotDeleted:
+			http.Error(w, "comment is not deleted", http.StatusConflict)
+		default:
+			slog.Error("failed to restore comment", "error", err, "comment_id", id, "user_id", uid)
+			http.Error(w, "failed to restore comment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, comment)
+}
+
+func (h *Handler) HideComment(w http.ResponseWriter, r *http.Request) {
+	h.setHidden(w, r, true)
+}
+
+func (h *Handler) UnhideComment(w http.ResponseWriter, r *http.Request) {
+	h.setHidden(w, r, false)
+}
+
+func (h *Handler) setHidden(w http.ResponseWriter, r *http.Request, hidden bool) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	var comment CommentResponse
+	if hidden {
+		comment, err = h.service.HideComment(r.Context(), int32(id), uid)
+	} else {
+		comment, err = h.service.UnhideComment(r.Context(), int32(id), uid)
+	}
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		case ErrCommentForbidden:
+			http.Error(w, "only the post author can hide comments", http.StatusForbidden)
+		default:
+			slog.Error("failed to set comment hidden", "error", err, "comment_id", id, "user_id", uid, "hidden", hidden)
+			http.Error(w, "failed to update comment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, comment)
+}
+
+func (h *Handler) GetCommentIncludingDeleted(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.FindCommentByIDIncludingDeleted(r.Context(), int32(id))
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		default:
+			http.Error(w, "failed to find comment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, comment)
+}
+
+func (h *Handler) ListCommentsByPostIDIncludingDeleted(w http.ResponseWriter, r *http.Request) {
 	postIDStr := chi.URLParam(r, "post_id")
 	postID, err := strconv.Atoi(postIDStr)
 	if err != nil {
@@ -137,9 +239,13 @@ func (h *Handler) ListCommentsByPostID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	comments, err := h.service.ListCommentsByPostID(r.Context(), int32(postID), p.Limit, p.Offset)
+	comments, err := h.service.ListCommentsByPostIDIncludingDeleted(r.Context(), int32(postID), p)
 	if err != nil {
 		slog.Error("failed to list comments", "error", err, "post_id", postID)
 		http.Error(w, "failed to list comments", http.StatusInternalServerError)
@@ -148,3 +254,153 @@ func (h *Handler) ListCommentsByPostID(w http.ResponseWriter, r *http.Request) {
 
 	json.Write(w, http.StatusOK, comments)
 }
+
+func (h *Handler) ListCommentsByPostID(w http.ResponseWriter, r *http.Request) {
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	sort := r.URL.Query().Get("sort")
+
+	comments, err := h.service.ListCommentsByPostID(r.Context(), int32(postID), auth.UserIDFromContext(r.Context()), sort, p)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrInvalidSort:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to list comments", "error", err, "post_id", postID)
+			http.Error(w, "failed to list comments", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, comments)
+}
+
+func (h *Handler) ListReplies(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListReplies(r.Context(), int32(id), auth.UserIDFromContext(r.Context()), p)
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to list replies", "error", err, "comment_id", id)
+			http.Error(w, "failed to list replies", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, page)
+}
+
+func (h *Handler) GetThread(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	thread, err := h.service.GetThread(r.Context(), int32(id), auth.UserIDFromContext(r.Context()))
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to get comment thread", "error", err, "comment_id", id)
+			http.Error(w, "failed to get comment thread", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, thread)
+}
+
+func (h *Handler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.service.ListRevisions(r.Context(), int32(id), auth.UserIDFromContext(r.Context()), p)
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to list comment revisions", "error", err, "comment_id", id)
+			http.Error(w, "failed to list comment revisions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, revisions)
+}
+
+func (h *Handler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	revisionIDStr := chi.URLParam(r, "revision_id")
+	revisionID, err := strconv.Atoi(revisionIDStr)
+	if err != nil {
+		http.Error(w, "invalid revision id", http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.RestoreRevision(r.Context(), int32(id), int32(revisionID), uid)
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		case ErrRevisionNotFound:
+			http.Error(w, "revision not found", http.StatusNotFound)
+		case ErrCommentForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		default:
+			slog.Error("failed to restore comment revision", "error", err, "comment_id", id, "revision_id", revisionID)
+			http.Error(w, "failed to restore comment revision", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, comment)
+}
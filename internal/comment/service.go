@@ -3,56 +3,193 @@ package comment
 import (
 	"context"
 	"errors"
+	"slices"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/internal/reaction"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/markdown"
+	"github.com/etherealsense/social-network/pkg/mention"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/etherealsense/social-network/pkg/textsearch"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Sort orders for the top-level comments of a post. SortTop ranks by likes
+// and replies with a time decay, like the feed score.
+const (
+	SortOldest = "oldest"
+	SortNewest = "newest"
+	SortTop    = "top"
+)
+
+const (
+	// MaxDepth is the deepest level a reply can be nested at. Top-level
+	// comments are at depth 0.
+	MaxDepth = 5
+	// ReplyPreviewSize is how many replies are embedded in each top-level
+	// comment when listing the comments of a post.
+	ReplyPreviewSize = 3
+	// MaxThreadSize bounds the replies returned by GetThread.
+	MaxThreadSize = 500
+)
+
 var (
-	ErrCommentNotFound  = errors.New("comment not found")
-	ErrCommentForbidden = errors.New("forbidden")
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrCommentForbidden  = errors.New("forbidden")
+	ErrPostNotFound      = errors.New("post not found")
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrCommentNotDeleted = errors.New("comment is not deleted")
+	ErrInvalidLanguage   = errors.New("invalid language")
+	ErrParentNotFound    = errors.New("parent comment not found")
+	ErrThreadTooDeep     = errors.New("replies cannot be nested more than 5 levels deep")
+	ErrInvalidSort       = errors.New("sort must be one of oldest, newest or top")
+	ErrCommentsLocked    = errors.New("comments are locked on this post")
+	ErrCommentNotAllowed = errors.New("the author has limited who can comment on this post")
 )
 
 type Service interface {
-	CreateComment(ctx context.Context, postID, userID int32, req CreateCommentRequest) (repo.Comment, error)
-	FindCommentByID(ctx context.Context, id int32) (repo.Comment, error)
-	UpdateComment(ctx context.Context, id int32, userID int32, req UpdateCommentRequest) (repo.Comment, error)
+	CreateComment(ctx context.Context, postID, userID int32, req CreateCommentRequest) (CommentResponse, error)
+	FindCommentByID(ctx context.Context, id, viewerID int32) (CommentResponse, error)
+	UpdateComment(ctx context.Context, id int32, userID int32, req UpdateCommentRequest) (CommentResponse, error)
 	DeleteComment(ctx context.Context, id int32, userID int32) error
-	ListCommentsByPostID(ctx context.Context, postID int32, limit, offset int32) ([]repo.Comment, error)
+	RestoreComment(ctx context.Context, id int32, userID int32) (CommentResponse, error)
+	HideComment(ctx context.Context, id int32, userID int32) (CommentResponse, error)
+	UnhideComment(ctx context.Context, id int32, userID int32) (CommentResponse, error)
+	FindCommentByIDIncludingDeleted(ctx context.Context, id int32) (CommentResponse, error)
+	ListCommentsByPostIDIncludingDeleted(ctx context.Context, postID int32, p pagination.Params) (pagination.Page[CommentResponse], error)
+	ListCommentsByPostID(ctx context.Context, postID, viewerID int32, sort string, p pagination.Params) (pagination.Page[CommentResponse], error)
+	ListReplies(ctx context.Context, commentID, viewerID int32, p pagination.Params) (pagination.Page[CommentResponse], error)
+	GetThread(ctx context.Context, commentID, viewerID int32) (CommentResponse, error)
+	ListRevisions(ctx context.Context, commentID, viewerID int32, p pagination.Params) (pagination.Page[repo.CommentRevision], error)
+	RestoreRevision(ctx context.Context, commentID, revisionID, userID int32) (CommentResponse, error)
 }
 
 type svc struct {
-	repo repo.Querier
+	repo      repo.Querier
+	tx        database.Transactor
+	reactions reaction.Service
 }
 
-func NewService(repo repo.Querier) Service {
-	return &svc{repo: repo}
+func NewService(repo repo.Querier, tx database.Transactor, reactions reaction.Service) Service {
+	return &svc{repo: repo, tx: tx, reactions: reactions}
 }
 
-func (s *svc) CreateComment(ctx context.Context, postID, userID int32, req CreateCommentRequest) (repo.Comment, error) {
-	return s.repo.CreateComment(ctx, repo.CreateCommentParams{
-		PostID:  postID,
-		UserID:  userID,
-		Content: req.Content,
+// CreateComment adds a comment or reply to a post. The post author can
+// always comment; anyone else is held to the lock and comment policy of the
+// post.
+func (s *svc) CreateComment(ctx context.Context, postID, userID int32, req CreateCommentRequest) (CommentResponse, error) {
+	p, err := s.findVisiblePost(ctx, postID, userID)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	if p.CommentsLocked && p.UserID != userID {
+		return CommentResponse{}, ErrCommentsLocked
+	}
+
+	allowed, err := s.repo.CanCommentOnPost(ctx, repo.CanCommentOnPostParams{
+		UserID: userID,
+		ID:     postID,
+	})
+	if err != nil {
+		return CommentResponse{}, err
+	}
+	if !allowed {
+		return CommentResponse{}, ErrCommentNotAllowed
+	}
+
+	var language pgtype.Text
+	if req.Language != "" {
+		if !textsearch.ValidLanguage(req.Language) {
+			return CommentResponse{}, ErrInvalidLanguage
+		}
+		language = pgtype.Text{String: req.Language, Valid: true}
+	}
+
+	var parentID pgtype.Int4
+	if req.ParentID != nil {
+		parent, err := s.repo.FindCommentByID(ctx, *req.ParentID)
+		if err != nil || parent.PostID != postID || !canSee(parent, p, userID) {
+			return CommentResponse{}, ErrParentNotFound
+		}
+		if parent.Depth >= MaxDepth {
+			return CommentResponse{}, ErrThreadTooDeep
+		}
+		parentID = pgtype.Int4{Int32: parent.ID, Valid: true}
+	}
+
+	var c repo.Comment
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		c, err = s.repo.CreateComment(ctx, repo.CreateCommentParams{
+			PostID:   postID,
+			UserID:   userID,
+			Content:  req.Content,
+			Language: language,
+			ParentID: parentID,
+		})
+		if err != nil {
+			return err
+		}
+		return s.setMentions(ctx, &c)
 	})
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	return s.toResponse(ctx, c, userID)
 }
 
-func (s *svc) FindCommentByID(ctx context.Context, id int32) (repo.Comment, error) {
+func (s *svc) FindCommentByID(ctx context.Context, id, viewerID int32) (CommentResponse, error) {
+	c, err := s.findVisibleComment(ctx, id, viewerID)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+	return s.toResponse(ctx, c, viewerID)
+}
+
+// findVisibleComment returns the comment when viewerID may read the post it
+// belongs to, and the comment itself if it is hidden.
+func (s *svc) findVisibleComment(ctx context.Context, id, viewerID int32) (repo.Comment, error) {
 	c, err := s.repo.FindCommentByID(ctx, id)
 	if err != nil {
 		return repo.Comment{}, ErrCommentNotFound
 	}
+
+	p, err := s.findVisiblePost(ctx, c.PostID, viewerID)
+	if err != nil || !canSee(c, p, viewerID) {
+		return repo.Comment{}, ErrCommentNotFound
+	}
 	return c, nil
 }
 
-func (s *svc) UpdateComment(ctx context.Context, id int32, userID int32, req UpdateCommentRequest) (repo.Comment, error) {
+func (s *svc) findVisiblePost(ctx context.Context, postID, viewerID int32) (repo.Post, error) {
+	p, err := s.repo.FindVisiblePostByID(ctx, repo.FindVisiblePostByIDParams{
+		ID:       postID,
+		ViewerID: viewerID,
+	})
+	if err != nil || p.Status != post.StatusPublished {
+		return repo.Post{}, ErrPostNotFound
+	}
+	return p, nil
+}
+
+// canSee reports whether viewerID may read c. Hidden comments are only shown
+// to their author and the author of the post.
+func canSee(c repo.Comment, p repo.Post, viewerID int32) bool {
+	return !c.HiddenAt.Valid || viewerID == c.UserID || viewerID == p.UserID
+}
+
+func (s *svc) UpdateComment(ctx context.Context, id int32, userID int32, req UpdateCommentRequest) (CommentResponse, error) {
 	c, err := s.repo.FindCommentByID(ctx, id)
 	if err != nil {
-		return repo.Comment{}, ErrCommentNotFound
+		return CommentResponse{}, ErrCommentNotFound
 	}
 
 	if c.UserID != userID {
-		return repo.Comment{}, ErrCommentForbidden
+		return CommentResponse{}, ErrCommentForbidden
 	}
 
 	params := repo.UpdateCommentParams{
@@ -63,9 +200,26 @@ func (s *svc) UpdateComment(ctx context.Context, id int32, userID int32, req Upd
 		params.Content = pgtype.Text{String: *req.Content, Valid: true}
 	}
 
-	return s.repo.UpdateComment(ctx, params)
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		c, err = s.repo.UpdateComment(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if req.Content != nil {
+			return s.setMentions(ctx, &c)
+		}
+		return nil
+	})
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	return s.toResponse(ctx, c, userID)
 }
 
+// DeleteComment soft deletes a comment. Both the comment author and the post
+// author may delete it.
 func (s *svc) DeleteComment(ctx context.Context, id int32, userID int32) error {
 	c, err := s.repo.FindCommentByID(ctx, id)
 	if err != nil {
@@ -73,16 +227,480 @@ func (s *svc) DeleteComment(ctx context.Context, id int32, userID int32) error {
 	}
 
 	if c.UserID != userID {
+		p, err := s.repo.FindPostByID(ctx, c.PostID)
+		if err != nil {
+			return ErrCommentNotFound
+		}
+		if p.UserID != userID {
+			return ErrCommentForbidden
+		}
+	}
+
+	return s.repo.DeleteComment(ctx, repo.DeleteCommentParams{
+		ID:        id,
+		DeletedBy: pgtype.Int4{Int32: userID, Valid: true},
+	})
+}
+
+// RestoreComment undoes DeleteComment. Only the user who deleted the comment
+// can restore it, so a comment removed by the post author stays removed.
+func (s *svc) RestoreComment(ctx context.Context, id int32, userID int32) (CommentResponse, error) {
+	c, err := s.repo.FindCommentByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return CommentResponse{}, ErrCommentNotFound
+	}
+
+	deletedBy := c.UserID
+	if c.DeletedBy.Valid {
+		deletedBy = c.DeletedBy.Int32
+	}
+
+	if deletedBy != userID {
+		return CommentResponse{}, ErrCommentForbidden
+	}
+
+	if !c.DeletedAt.Valid {
+		return CommentResponse{}, ErrCommentNotDeleted
+	}
+
+	c, err = s.repo.RestoreComment(ctx, id)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	return s.toResponse(ctx, c, userID)
+}
+
+// HideComment hides a comment from everyone but its author and the post
+// author. Only the post author can hide comments.
+func (s *svc) HideComment(ctx context.Context, id int32, userID int32) (CommentResponse, error) {
+	if err := s.checkPostAuthor(ctx, id, userID); err != nil {
+		return CommentResponse{}, err
+	}
+
+	c, err := s.repo.HideComment(ctx, id)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	return s.toResponse(ctx, c, userID)
+}
+
+func (s *svc) UnhideComment(ctx context.Context, id int32, userID int32) (CommentResponse, error) {
+	if err := s.checkPostAuthor(ctx, id, userID); err != nil {
+		return CommentResponse{}, err
+	}
+
+	c, err := s.repo.UnhideComment(ctx, id)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	return s.toResponse(ctx, c, userID)
+}
+
+// checkPostAuthor returns ErrCommentForbidden unless userID wrote the post
+// the comment belongs to.
+func (s *svc) checkPostAuthor(ctx context.Context, commentID, userID int32) error {
+	c, err := s.repo.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+
+	p, err := s.repo.FindPostByID(ctx, c.PostID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+
+	if p.UserID != userID {
 		return ErrCommentForbidden
 	}
+	return nil
+}
+
+// FindCommentByIDIncludingDeleted returns the comment even when it has been
+// soft deleted, so moderators can inspect tombstones.
+func (s *svc) FindCommentByIDIncludingDeleted(ctx context.Context, id int32) (CommentResponse, error) {
+	c, err := s.repo.FindCommentByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return CommentResponse{}, ErrCommentNotFound
+	}
+	return s.toResponse(ctx, c, 0)
+}
+
+func (s *svc) ListCommentsByPostIDIncludingDeleted(ctx context.Context, postID int32, p pagination.Params) (pagination.Page[CommentResponse], error) {
+	comments, err := s.repo.ListCommentsByPostIDIncludingDeleted(ctx, repo.ListCommentsByPostIDIncludingDeletedParams{
+		PostID:     postID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+
+	comments, c := pagination.Paginate(comments, p, func(c repo.Comment) pagination.Key {
+		return pagination.Key{Time: c.CreatedAt.Time, ID: c.ID}
+	})
 
-	return s.repo.DeleteComment(ctx, id)
+	res, err := s.toResponses(ctx, comments, 0)
+	if err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
 }
 
-func (s *svc) ListCommentsByPostID(ctx context.Context, postID int32, limit, offset int32) ([]repo.Comment, error) {
-	return s.repo.ListCommentsByPostID(ctx, repo.ListCommentsByPostIDParams{
-		PostID: postID,
-		Limit:  limit,
-		Offset: offset,
+// ListCommentsByPostID lists the top-level comments of a post in the given
+// sort order. Every order is ranked by one descending key, so one cursor
+// format serves them all; top is scored as of the time of the first page.
+func (s *svc) ListCommentsByPostID(ctx context.Context, postID, viewerID int32, sort string, p pagination.Params) (pagination.Page[CommentResponse], error) {
+	switch sort {
+	case "":
+		sort = SortOldest
+	case SortOldest, SortNewest, SortTop:
+	default:
+		return pagination.Page[CommentResponse]{}, ErrInvalidSort
+	}
+
+	if _, err := s.findVisiblePost(ctx, postID, viewerID); err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+
+	asOf := p.AsOf()
+	rows, err := s.repo.ListCommentsByPostID(ctx, repo.ListCommentsByPostIDParams{
+		Sort:        sort,
+		AsOf:        pgtype.Timestamptz{Time: asOf, Valid: true},
+		PostID:      postID,
+		ViewerID:    viewerID,
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
 	})
+	if err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.ListCommentsByPostIDRow) pagination.Key {
+		return pagination.Key{Time: asOf, Score: r.Rank, ID: r.Tiebreak}
+	})
+
+	comments := make([]repo.Comment, len(rows))
+	for i, row := range rows {
+		comments[i] = row.Comment
+	}
+
+	res, err := s.toResponses(ctx, comments, viewerID)
+	if err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+
+	if err := s.embedReplies(ctx, res, viewerID); err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+// embedReplies attaches the first ReplyPreviewSize replies of each comment.
+// Deeper levels are loaded with ListReplies.
+func (s *svc) embedReplies(ctx context.Context, comments []CommentResponse, viewerID int32) error {
+	ids := make([]int32, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+
+	replies, err := s.repo.ListRepliesByParentIDs(ctx, repo.ListRepliesByParentIDsParams{
+		ParentIds:        ids,
+		ViewerID:         viewerID,
+		RepliesPerParent: ReplyPreviewSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	hydrated, err := s.toResponses(ctx, replies, viewerID)
+	if err != nil {
+		return err
+	}
+
+	byParent := make(map[int32][]CommentResponse)
+	for _, r := range hydrated {
+		byParent[r.ParentID.Int32] = append(byParent[r.ParentID.Int32], r)
+	}
+
+	for i := range comments {
+		comments[i].Replies = byParent[comments[i].ID]
+		setRepliesCursor(&comments[i])
+	}
+	return nil
+}
+
+// ListReplies returns the direct replies of a comment, oldest first.
+func (s *svc) ListReplies(ctx context.Context, commentID, viewerID int32, p pagination.Params) (pagination.Page[CommentResponse], error) {
+	if _, err := s.findVisibleComment(ctx, commentID, viewerID); err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+
+	replies, err := s.repo.ListReplies(ctx, repo.ListRepliesParams{
+		ParentID: commentID,
+		ViewerID: viewerID,
+		CursorID: p.CursorID(),
+		Backward: p.Backward(),
+		Limit:    p.Fetch(),
+		Offset:   p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+
+	replies, c := pagination.Paginate(replies, p, func(r repo.Comment) pagination.Key {
+		return pagination.Key{ID: r.ID}
+	})
+
+	res, err := s.toResponses(ctx, replies, viewerID)
+	if err != nil {
+		return pagination.Page[CommentResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+// GetThread returns a comment with its replies nested under it, up to
+// MaxThreadSize replies. Shallower replies are loaded first, so a cut-off
+// thread misses its deepest levels. Replies to deleted comments, and to hidden
+// ones the viewer cannot see, are left out along with their parent.
+func (s *svc) GetThread(ctx context.Context, commentID, viewerID int32) (CommentResponse, error) {
+	root, err := s.findVisibleComment(ctx, commentID, viewerID)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	replies, err := s.repo.ListCommentSubtree(ctx, repo.ListCommentSubtreeParams{
+		RootID:   commentID,
+		ViewerID: viewerID,
+		Limit:    MaxThreadSize,
+	})
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	nodes, err := s.toResponses(ctx, append([]repo.Comment{root}, replies...), viewerID)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	index := make(map[int32]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID] = i
+	}
+
+	// Replies come ordered by depth, so walking backwards completes every
+	// comment before it is copied into its parent.
+	for i := len(nodes) - 1; i > 0; i-- {
+		slices.Reverse(nodes[i].Replies)
+		setRepliesCursor(&nodes[i])
+
+		parent := index[nodes[i].ParentID.Int32]
+		nodes[parent].Replies = append(nodes[parent].Replies, nodes[i])
+	}
+
+	slices.Reverse(nodes[0].Replies)
+	setRepliesCursor(&nodes[0])
+	return nodes[0], nil
+}
+
+func setRepliesCursor(c *CommentResponse) {
+	if n := len(c.Replies); n > 0 && int64(n) < c.RepliesCount {
+		c.RepliesCursor = pagination.EncodeCursor(pagination.Cursor{Key: pagination.Key{ID: c.Replies[n-1].ID}})
+	}
+}
+
+func (s *svc) ListRevisions(ctx context.Context, commentID, viewerID int32, p pagination.Params) (pagination.Page[repo.CommentRevision], error) {
+	if _, err := s.findVisibleComment(ctx, commentID, viewerID); err != nil {
+		return pagination.Page[repo.CommentRevision]{}, err
+	}
+
+	revisions, err := s.repo.ListCommentRevisions(ctx, repo.ListCommentRevisionsParams{
+		CommentID:  commentID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.CommentRevision]{}, err
+	}
+
+	revisions, c := pagination.Paginate(revisions, p, func(r repo.CommentRevision) pagination.Key {
+		return pagination.Key{Time: r.CreatedAt.Time, ID: r.ID}
+	})
+	return pagination.NewPage(revisions, c), nil
+}
+
+// RestoreRevision makes an older revision current again. The version being
+// replaced is itself kept as a new revision.
+func (s *svc) RestoreRevision(ctx context.Context, commentID, revisionID, userID int32) (CommentResponse, error) {
+	c, err := s.repo.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return CommentResponse{}, ErrCommentNotFound
+	}
+
+	if c.UserID != userID {
+		return CommentResponse{}, ErrCommentForbidden
+	}
+
+	rev, err := s.repo.FindCommentRevisionByID(ctx, repo.FindCommentRevisionByIDParams{
+		ID:        revisionID,
+		CommentID: commentID,
+	})
+	if err != nil {
+		return CommentResponse{}, ErrRevisionNotFound
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		c, err = s.repo.UpdateComment(ctx, repo.UpdateCommentParams{
+			ID:      commentID,
+			Content: pgtype.Text{String: rev.Content, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return s.setMentions(ctx, &c)
+	})
+	if err != nil {
+		return CommentResponse{}, err
+	}
+
+	return s.toResponse(ctx, c, userID)
+}
+
+// setMentions replaces the mentions of the comment with the ones that
+// resolve in its current content and renders the content to HTML.
+func (s *svc) setMentions(ctx context.Context, c *repo.Comment) error {
+	users, err := s.repo.ResolveMentions(ctx, repo.ResolveMentionsParams{
+		Handles:  mention.Handles(c.Content),
+		AuthorID: c.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int32, len(users))
+	handles := make(map[string]int32, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+		handles[u.Handle] = u.ID
+	}
+
+	err = s.repo.SetCommentMentions(ctx, repo.SetCommentMentionsParams{
+		CommentID: c.ID,
+		UserIds:   ids,
+		AuthorID:  c.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ContentHtml = markdown.Render(c.Content, handles)
+	return s.repo.SetCommentContentHTML(ctx, repo.SetCommentContentHTMLParams{
+		ID:          c.ID,
+		ContentHtml: c.ContentHtml,
+	})
+}
+
+// toResponses attaches the mentions, like, reaction and reply counts of each
+// comment. Liked, the viewer's reaction and the reply counts depend on
+// viewerID, since hidden replies count for their author and the post author.
+func (s *svc) toResponses(ctx context.Context, comments []repo.Comment, viewerID int32) ([]CommentResponse, error) {
+	ids := make([]int32, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+
+	rows, err := s.repo.ListMentionsByCommentIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.CountRepliesByCommentIDs(ctx, repo.CountRepliesByCommentIDsParams{
+		CommentIds: ids,
+		ViewerID:   viewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	replies := make(map[int32]int64, len(counts))
+	for _, c := range counts {
+		replies[c.CommentID] = c.Count
+	}
+
+	likeCounts, err := s.repo.CountLikesByCommentIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	likes := make(map[int32]int64, len(likeCounts))
+	for _, c := range likeCounts {
+		likes[c.CommentID] = c.Count
+	}
+
+	reactions, err := s.reactions.ListByCommentIDs(ctx, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	liked := make(map[int32]bool)
+	if viewerID != 0 {
+		likedIDs, err := s.repo.ListLikedCommentIDs(ctx, repo.ListLikedCommentIDsParams{
+			UserID:     viewerID,
+			CommentIds: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range likedIDs {
+			liked[id] = true
+		}
+	}
+
+	mentioned := make(map[int32]map[string]int32)
+	for _, row := range rows {
+		if mentioned[row.CommentID] == nil {
+			mentioned[row.CommentID] = make(map[string]int32)
+		}
+		mentioned[row.CommentID][row.Handle] = row.UserID
+	}
+
+	res := make([]CommentResponse, len(comments))
+	for i, c := range comments {
+		res[i] = CommentResponse{
+			Comment:        c,
+			Mentions:       mention.Entities(c.Content, mentioned[c.ID]),
+			RepliesCount:   replies[c.ID],
+			LikesCount:     likes[c.ID],
+			Liked:          liked[c.ID],
+			Reactions:      reactions[c.ID].Counts,
+			ViewerReaction: reactions[c.ID].Viewer,
+		}
+		if markdown.HTMLRequested(ctx) {
+			res[i].ContentHTML = c.ContentHtml
+			// Comments written before content_html existed.
+			if c.ContentHtml == "" && c.Content != "" {
+				res[i].ContentHTML = markdown.Render(c.Content, mentioned[c.ID])
+			}
+		}
+	}
+	return res, nil
+}
+
+func (s *svc) toResponse(ctx context.Context, c repo.Comment, viewerID int32) (CommentResponse, error) {
+	res, err := s.toResponses(ctx, []repo.Comment{c}, viewerID)
+	if err != nil {
+		return CommentResponse{}, err
+	}
+	return res[0], nil
 }
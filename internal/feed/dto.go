@@ -0,0 +1,26 @@
+package feed
+
+import (
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FeedItem is a post in the feed. A post reposted by several followed users
+// appears once, with RepostedBy listing them, most recent first.
+type FeedItem struct {
+	post.PostResponse
+	LikesCount    int64              `json:"likes_count"`
+	CommentsCount int64              `json:"comments_count"`
+	RepostedBy    []int32            `json:"reposted_by"`
+	RepostedAt    pgtype.Timestamptz `json:"reposted_at"`
+	Score         float64            `json:"score"`
+}
+
+// ExploreItem is a post in the explore feed. Score is its engagement
+// velocity when the snapshot was computed.
+type ExploreItem struct {
+	post.PostResponse
+	LikesCount    int64   `json:"likes_count"`
+	CommentsCount int64   `json:"comments_count"`
+	Score         float64 `json:"score"`
+}
@@ -0,0 +1,166 @@
+package feed
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// ExploreWindow is how recently a post must have been published to be
+	// explored.
+	ExploreWindow = 48 * time.Hour
+	// ExploreAuthorCap is the most posts one author has in an explore
+	// snapshot, so a single prolific account cannot fill the feed.
+	ExploreAuthorCap = 3
+	// ExploreSize is the most posts in an explore snapshot.
+	ExploreSize = 500
+	// ExploreGravity sets how fast engagement loses weight with age. Posts
+	// rank by engagement velocity rather than by their total.
+	ExploreGravity = 1.5
+	// ExploreRetention is how long a snapshot is kept after a newer one
+	// replaces it. Cursors into it stop working after that.
+	ExploreRetention = time.Hour
+
+	exploreCandidates = 5000
+)
+
+// Explorer periodically recomputes the explore snapshot: recent public posts
+// ranked by engagement velocity, at most ExploreAuthorCap per author. Viewers
+// read the latest snapshot, minus authors they blocked or muted. When several
+// API instances run it, each round takes an advisory lock and skips computing
+// when another instance wrote a snapshot less than half an interval ago, so
+// about one snapshot is written per interval.
+type Explorer struct {
+	repo     repo.Querier
+	tx       database.Transactor
+	interval time.Duration
+}
+
+func NewExplorer(repo repo.Querier, tx database.Transactor, interval time.Duration) *Explorer {
+	return &Explorer{repo: repo, tx: tx, interval: interval}
+}
+
+// Run computes a snapshot right away, so the explore feed is not empty until
+// the first tick.
+func (e *Explorer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	e.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.refresh(ctx)
+		}
+	}
+}
+
+// refresh writes a snapshot unless another instance holds the lock or wrote
+// one recently. Half an interval leaves room for ticker jitter, so an instance
+// does not skip the round after its own snapshot.
+func (e *Explorer) refresh(ctx context.Context) {
+	err := e.tx.WithTx(ctx, func(ctx context.Context) error {
+		locked, err := e.repo.TryLockExploreSnapshot(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		now := time.Now()
+		latest, err := e.repo.LatestExploreSnapshotAt(ctx)
+		if err != nil {
+			return err
+		}
+		if latest.Valid && now.Sub(latest.Time) < e.interval/2 {
+			return nil
+		}
+		return e.snapshot(ctx, now)
+	})
+	if err != nil {
+		slog.Error("failed to refresh explore snapshot", "error", err)
+	}
+}
+
+// snapshot writes the explore snapshot as of now and drops the snapshots it
+// replaced more than ExploreRetention ago.
+func (e *Explorer) snapshot(ctx context.Context, now time.Time) error {
+	rows, err := e.repo.ListExploreCandidates(ctx, repo.ListExploreCandidatesParams{
+		Since: pgtype.Timestamptz{Time: now.Add(-ExploreWindow), Valid: true},
+		Limit: exploreCandidates,
+	})
+	if err != nil {
+		return err
+	}
+
+	picked := explore(rows, now)
+
+	params := repo.InsertExploreSnapshotParams{
+		ComputedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		PostIds:    make([]int32, len(picked)),
+		AuthorIds:  make([]int32, len(picked)),
+		Scores:     make([]float64, len(picked)),
+	}
+	for i, p := range picked {
+		params.PostIds[i] = p.row.PostID
+		params.AuthorIds[i] = p.row.AuthorID
+		params.Scores[i] = p.score
+	}
+
+	if err := e.repo.InsertExploreSnapshot(ctx, params); err != nil {
+		return err
+	}
+
+	cutoff := pgtype.Timestamptz{Time: now.Add(-ExploreRetention), Valid: true}
+	return e.repo.DeleteExploreSnapshotsBefore(ctx, cutoff)
+}
+
+type explored struct {
+	row   repo.ListExploreCandidatesRow
+	score float64
+}
+
+// explore ranks candidates by velocity and keeps the top ExploreSize, with
+// at most ExploreAuthorCap posts per author.
+func explore(rows []repo.ListExploreCandidatesRow, asOf time.Time) []explored {
+	all := make([]explored, len(rows))
+	for i, row := range rows {
+		all[i] = explored{row: row, score: velocity(row, asOf)}
+	}
+	slices.SortFunc(all, func(a, b explored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.row.PostID, a.row.PostID)
+	})
+
+	perAuthor := make(map[int32]int)
+	picked := make([]explored, 0, min(len(all), ExploreSize))
+	for _, p := range all {
+		if len(picked) == ExploreSize {
+			break
+		}
+		if perAuthor[p.row.AuthorID] == ExploreAuthorCap {
+			continue
+		}
+		perAuthor[p.row.AuthorID]++
+		picked = append(picked, p)
+	}
+	return picked
+}
+
+// velocity weighs comments and reposts above reactions and divides by a
+// power of age, like the decayed ranker.
+func velocity(row repo.ListExploreCandidatesRow, asOf time.Time) float64 {
+	engagement := float64(row.ReactionsCount) + 2*float64(row.CommentsCount) + 3*float64(row.RepostsCount)
+	hours := max(asOf.Sub(row.PublishedAt.Time).Hours(), 0)
+	return engagement / math.Pow(hours+2, ExploreGravity)
+}
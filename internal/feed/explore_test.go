@@ -0,0 +1,47 @@
+package feed
+
+import (
+	"slices"
+	"testing"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func candidate(id, author int32, hours float64, reactions int64) repo.ListExploreCandidatesRow {
+	return repo.ListExploreCandidatesRow{
+		PostID:         id,
+		AuthorID:       author,
+		PublishedAt:    pgtype.Timestamptz{Time: hoursAgo(hours), Valid: true},
+		ReactionsCount: reactions,
+	}
+}
+
+func TestExploreAuthorCap(t *testing.T) {
+	var rows []repo.ListExploreCandidatesRow
+	for i := range int32(5) {
+		rows = append(rows, candidate(i+1, 1, 1, 100-int64(i)))
+	}
+	rows = append(rows, candidate(6, 2, 1, 10))
+
+	picked := explore(rows, testAsOf)
+
+	var got []int32
+	for _, p := range picked {
+		got = append(got, p.row.PostID)
+	}
+	if want := []int32{1, 2, 3, 6}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestExploreVelocity(t *testing.T) {
+	// The same engagement gathered in an hour beats a day's worth.
+	fast := candidate(1, 1, 1, 50)
+	slow := candidate(2, 2, 24, 50)
+
+	picked := explore([]repo.ListExploreCandidatesRow{slow, fast}, testAsOf)
+	if picked[0].row.PostID != fast.PostID {
+		t.Errorf("ranked post %d first, want %d", picked[0].row.PostID, fast.PostID)
+	}
+}
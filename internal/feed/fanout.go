@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// TimelineRetention is how far back the feed reaches. Older timeline
+	// entries are pruned.
+	TimelineRetention = 30 * 24 * time.Hour
+	// BackfillSize is how many recent posts of a user are added to the
+	// timeline of a new follower.
+	BackfillSize = 100
+	// MaxFanoutFollowers is the most followers an author can have and still
+	// have their posts copied into every follower's timeline. Beyond it the
+	// author switches to fan-out on read for good, so a follower count that
+	// hovers around the limit does not leave gaps in timelines.
+	MaxFanoutFollowers = 10000
+
+	fanoutBatchSize = 50
+	// fanoutClaimTimeout releases claims of a worker that died mid-fan-out.
+	fanoutClaimTimeout = time.Minute
+)
+
+// Fanout writes newly published posts into the timelines of their authors'
+// followers and prunes expired timeline entries. Queued posts are claimed
+// with FOR UPDATE SKIP LOCKED, so several API instances can run it side by
+// side.
+type Fanout struct {
+	repo     repo.Querier
+	interval time.Duration
+}
+
+func NewFanout(repo repo.Querier, interval time.Duration) *Fanout {
+	return &Fanout{repo: repo, interval: interval}
+}
+
+func (f *Fanout) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.fanOutQueued(ctx)
+			f.prune(ctx)
+		}
+	}
+}
+
+func (f *Fanout) fanOutQueued(ctx context.Context) {
+	for {
+		rows, err := f.repo.ClaimTimelineFanout(ctx, repo.ClaimTimelineFanoutParams{
+			ClaimExpiredBefore: pgtype.Timestamptz{Time: time.Now().Add(-fanoutClaimTimeout), Valid: true},
+			Limit:              fanoutBatchSize,
+		})
+		if err != nil {
+			slog.Error("failed to claim timeline fan-out", "error", err)
+			return
+		}
+
+		for _, row := range rows {
+			if err := f.fanOut(ctx, row); err != nil {
+				slog.Error("failed to fan out post", "error", err, "post_id", row.PostID)
+			}
+		}
+
+		if len(rows) < fanoutBatchSize {
+			return
+		}
+	}
+}
+
+// fanOut copies a post into the timelines of its author's followers, unless
+// the author has too many followers, in which case the feed picks the post
+// up on read.
+func (f *Fanout) fanOut(ctx context.Context, row repo.ClaimTimelineFanoutRow) error {
+	fanoutOnRead := row.FanoutOnRead
+	if !fanoutOnRead {
+		followers, err := f.repo.CountFollowers(ctx, row.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		if followers > MaxFanoutFollowers {
+			if err := f.repo.SetUserFanoutOnRead(ctx, row.AuthorID); err != nil {
+				return err
+			}
+			slog.Info("switched author to fan-out on read", "user_id", row.AuthorID, "followers", followers)
+			fanoutOnRead = true
+		}
+	}
+
+	if !fanoutOnRead {
+		if err := f.repo.FanOutPost(ctx, row.PostID); err != nil {
+			return err
+		}
+	}
+
+	return f.repo.DeleteTimelineFanout(ctx, row.PostID)
+}
+
+func (f *Fanout) prune(ctx context.Context) {
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-TimelineRetention), Valid: true}
+
+	n, err := f.repo.DeleteExpiredTimelineEntries(ctx, cutoff)
+	if err != nil {
+		slog.Error("failed to prune timelines", "error", err)
+		return
+	}
+
+	if n > 0 {
+		slog.Info("pruned timelines", "entries", n)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/etherealsense/social-network/internal/auth"
 	"github.com/etherealsense/social-network/pkg/json"
 	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/go-chi/chi/v5"
 )
 
 type Handler struct {
@@ -17,16 +18,89 @@ func NewHandler(service Service) *Handler {
 	return &Handler{service: service}
 }
 
+// GetFeed handles GET /feed?ranking=chronological|weighted|decayed. The
+// ranking defaults to the user's feed_ranking setting.
 func (h *Handler) GetFeed(w http.ResponseWriter, r *http.Request) {
 	uid := auth.UserIDFromContext(r.Context())
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	posts, err := h.service.GetFeed(r.Context(), uid, r.URL.Query().Get("ranking"), p)
+	if err != nil {
+		switch err {
+		case ErrInvalidRanking:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrUserNotFound:
+			http.Error(w, "user not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to get feed", "error", err, "user_id", uid)
+			http.Error(w, "failed to get feed", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, posts)
+}
+
+// GetExplore handles GET /feed/explore. Anonymous viewers get the unfiltered
+// snapshot.
+func (h *Handler) GetExplore(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	posts, err := h.service.GetFeed(r.Context(), uid, p.Limit, p.Offset)
+	posts, err := h.service.GetExplore(r.Context(), uid, p)
 	if err != nil {
-		slog.Error("failed to get feed", "error", err, "user_id", uid)
-		http.Error(w, "failed to get feed", http.StatusInternalServerError)
+		slog.Error("failed to get explore feed", "error", err, "user_id", uid)
+		http.Error(w, "failed to get explore feed", http.StatusInternalServerError)
 		return
 	}
 
 	json.Write(w, http.StatusOK, posts)
 }
+
+func (h *Handler) ListRankings(w http.ResponseWriter, r *http.Request) {
+	rankings, err := h.service.ListRankings(r.Context())
+	if err != nil {
+		slog.Error("failed to list feed rankings", "error", err)
+		http.Error(w, "failed to list feed rankings", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, rankings)
+}
+
+func (h *Handler) UpdateRanking(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	ranking := chi.URLParam(r, "ranking")
+
+	var req Weights
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read update ranking request", "error", err)
+		http.Error(w, "failed to read update ranking request", http.StatusBadRequest)
+		return
+	}
+
+	weights, err := h.service.UpdateRanking(r.Context(), ranking, req)
+	if err != nil {
+		switch err {
+		case ErrRankingNotFound:
+			http.Error(w, "ranking not found", http.StatusNotFound)
+		case ErrInvalidWeights:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to update feed ranking", "error", err, "ranking", ranking, "user_id", uid)
+			http.Error(w, "failed to update feed ranking", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	slog.Info("updated feed ranking", "ranking", ranking, "user_id", uid)
+	json.Write(w, http.StatusOK, weights)
+}
@@ -0,0 +1,110 @@
+package feed
+
+import (
+	"math"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/user"
+)
+
+// Candidate is a feed post as seen by a Ranker. ActiveAt is when it was
+// published or last reposted by a followed user.
+type Candidate struct {
+	ActiveAt time.Time
+	Likes    int64
+	Comments int64
+}
+
+// Ranker scores feed candidates. The feed lists higher scores first.
+//
+// GetFeed scores posts in SQL so that it can page by score. Params sets the
+// ranking and weights the query scores with, and Score is the formula the
+// query evaluates for them.
+type Ranker interface {
+	Score(c Candidate, asOf time.Time) float64
+	Params(p *repo.GetFeedParams)
+}
+
+// Weights tune the weighted and decayed rankers. They are stored per ranking
+// in feed_ranking_weights, so moderators can change them at runtime.
+type Weights struct {
+	Likes    float64 `json:"likes"`
+	Comments float64 `json:"comments"`
+	Age      float64 `json:"age"`
+	Gravity  float64 `json:"gravity"`
+}
+
+// DefaultWeights are used for a ranking without stored weights.
+var DefaultWeights = map[string]Weights{
+	user.FeedWeighted: {Likes: 0.5, Comments: 2, Age: 1},
+	user.FeedDecayed:  {Likes: 0.5, Comments: 2, Gravity: 1.8},
+}
+
+// NewRanker returns the ranker for a ranking name.
+func NewRanker(ranking string, w Weights) (Ranker, error) {
+	switch ranking {
+	case user.FeedChronological:
+		return Chronological{}, nil
+	case user.FeedWeighted:
+		return Weighted{w}, nil
+	case user.FeedDecayed:
+		return Decayed{w}, nil
+	}
+	return nil, ErrInvalidRanking
+}
+
+// Chronological ranks the most recently active posts first.
+type Chronological struct{}
+
+func (Chronological) Score(c Candidate, _ time.Time) float64 {
+	return float64(c.ActiveAt.Unix())
+}
+
+func (Chronological) Params(p *repo.GetFeedParams) {
+	p.Ranking = user.FeedChronological
+}
+
+// Weighted adds up weighted engagement and subtracts a linear penalty per
+// hour of age.
+type Weighted struct {
+	Weights
+}
+
+func (r Weighted) Score(c Candidate, asOf time.Time) float64 {
+	return r.engagement(c) - r.Age*hours(c, asOf)
+}
+
+func (r Weighted) Params(p *repo.GetFeedParams) {
+	p.Ranking = user.FeedWeighted
+	p.LikesWeight = r.Likes
+	p.CommentsWeight = r.Comments
+	p.AgeWeight = r.Age
+}
+
+// Decayed divides weighted engagement by a power of age, as Hacker News
+// does, so that new posts overtake older ones with more engagement.
+// Gravity sets how fast posts sink.
+type Decayed struct {
+	Weights
+}
+
+func (r Decayed) Score(c Candidate, asOf time.Time) float64 {
+	return (1 + r.engagement(c)) / math.Pow(hours(c, asOf)+2, r.Gravity)
+}
+
+func (r Decayed) Params(p *repo.GetFeedParams) {
+	p.Ranking = user.FeedDecayed
+	p.LikesWeight = r.Likes
+	p.CommentsWeight = r.Comments
+	p.Gravity = r.Gravity
+}
+
+func (w Weights) engagement(c Candidate) float64 {
+	return w.Likes*float64(c.Likes) + w.Comments*float64(c.Comments)
+}
+
+// hours is the age of the candidate at asOf, never negative.
+func hours(c Candidate, asOf time.Time) float64 {
+	return max(asOf.Sub(c.ActiveAt).Hours(), 0)
+}
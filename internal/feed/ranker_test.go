@@ -0,0 +1,47 @@
+package feed
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etherealsense/social-network/internal/user"
+)
+
+var testAsOf = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func hoursAgo(h float64) time.Time {
+	return testAsOf.Add(-time.Duration(h * float64(time.Hour)))
+}
+
+func TestWeightedAgePenalty(t *testing.T) {
+	r := Weighted{Weights{Likes: 1, Comments: 2, Age: 0.5}}
+
+	got := r.Score(Candidate{ActiveAt: hoursAgo(4), Likes: 3, Comments: 1}, testAsOf)
+	if want := 3.0 + 2 - 2; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Posts dated after asOf are not rewarded for it.
+	future := r.Score(Candidate{ActiveAt: testAsOf.Add(time.Hour)}, testAsOf)
+	if future != 0 {
+		t.Errorf("future post scored %v, want 0", future)
+	}
+}
+
+func TestNewRankerInvalid(t *testing.T) {
+	for _, ranking := range []string{"", "popular", "Weighted"} {
+		if _, err := NewRanker(ranking, Weights{}); !errors.Is(err, ErrInvalidRanking) {
+			t.Errorf("NewRanker(%q): got %v, want ErrInvalidRanking", ranking, err)
+		}
+	}
+}
+
+func TestDefaultWeights(t *testing.T) {
+	// Rankings with weights fall back to defaults when none are stored.
+	for _, ranking := range []string{user.FeedWeighted, user.FeedDecayed} {
+		if _, ok := DefaultWeights[ranking]; !ok {
+			t.Errorf("no default weights for %q", ranking)
+		}
+	}
+}
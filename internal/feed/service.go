@@ -2,26 +2,198 @@ package feed
 
 import (
 	"context"
+	"errors"
+	"math"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/impression"
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidRanking  = errors.New("ranking must be one of chronological, weighted or decayed")
+	ErrInvalidWeights  = errors.New("weights must be non-negative numbers")
+	ErrRankingNotFound = errors.New("ranking has no weights")
+	ErrUserNotFound    = errors.New("user not found")
 )
 
 type Service interface {
-	GetFeed(ctx context.Context, userID int32, limit, offset int32) ([]repo.GetFeedRow, error)
+	GetFeed(ctx context.Context, userID int32, ranking string, p pagination.Params) (pagination.Page[FeedItem], error)
+	GetExplore(ctx context.Context, viewerID int32, p pagination.Params) (pagination.Page[ExploreItem], error)
+	ListRankings(ctx context.Context) ([]repo.FeedRankingWeight, error)
+	UpdateRanking(ctx context.Context, ranking string, w Weights) (repo.FeedRankingWeight, error)
 }
 
 type svc struct {
-	repo repo.Querier
+	repo        repo.Querier
+	posts       post.Service
+	impressions impression.Recorder
+}
+
+func NewService(repo repo.Querier, posts post.Service, impressions impression.Recorder) Service {
+	return &svc{repo: repo, posts: posts, impressions: impressions}
+}
+
+// GetFeed returns the feed of the user, ordered by ranking, or by the user's
+// feed_ranking setting when ranking is empty. Posts are read from the user's
+// timeline, plus the recent posts of followed authors that are fanned out on
+// read, without the posts and reposts of muted users, and scored by the
+// ranker and paged in SQL. Scores may decay with age, so every page is scored
+// as of the time of the first page.
+func (s *svc) GetFeed(ctx context.Context, userID int32, ranking string, p pagination.Params) (pagination.Page[FeedItem], error) {
+	ranker, err := s.ranker(ctx, userID, ranking)
+	if err != nil {
+		return pagination.Page[FeedItem]{}, err
+	}
+
+	asOf := p.AsOf()
+
+	params := repo.GetFeedParams{
+		FollowerID:  userID,
+		AsOf:        pgtype.Timestamptz{Time: asOf, Valid: true},
+		Since:       pgtype.Timestamptz{Time: asOf.Add(-TimelineRetention), Valid: true},
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
+	}
+	ranker.Params(&params)
+
+	rows, err := s.repo.GetFeed(ctx, params)
+	if err != nil {
+		return pagination.Page[FeedItem]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.GetFeedRow) pagination.Key {
+		return pagination.Key{Time: asOf, Score: r.Score, ID: r.Post.ID}
+	})
+
+	posts := make([]repo.Post, len(rows))
+	ids := make([]int32, len(rows))
+	for i, row := range rows {
+		posts[i] = row.Post
+		ids[i] = row.Post.ID
+	}
+	s.impressions.Record(userID, ids...)
+
+	hydrated, err := s.posts.ToResponses(ctx, posts, userID)
+	if err != nil {
+		return pagination.Page[FeedItem]{}, err
+	}
+
+	items := make([]FeedItem, len(rows))
+	for i, row := range rows {
+		items[i] = FeedItem{
+			PostResponse:  hydrated[i],
+			LikesCount:    row.LikesCount,
+			CommentsCount: row.CommentsCount,
+			RepostedBy:    row.RepostedBy,
+			RepostedAt:    row.RepostedAt,
+			Score:         row.Score,
+		}
+	}
+	return pagination.NewPage(items, c), nil
 }
 
-func NewService(repo repo.Querier) Service {
-	return &svc{repo: repo}
+// ranker builds the ranker for ranking, falling back to the user's setting,
+// with the stored weights.
+func (s *svc) ranker(ctx context.Context, userID int32, ranking string) (Ranker, error) {
+	if ranking == "" {
+		r, err := s.repo.FindUserFeedRankingByID(ctx, userID)
+		if err != nil {
+			return nil, ErrUserNotFound
+		}
+		ranking = r
+	}
+	stored, err := s.repo.ListFeedRankingWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w := DefaultWeights[ranking]
+	for _, sw := range stored {
+		if sw.Ranking == ranking {
+			w = Weights{Likes: sw.Likes, Comments: sw.Comments, Age: sw.Age, Gravity: sw.Gravity}
+		}
+	}
+	return NewRanker(ranking, w)
 }
 
-func (s *svc) GetFeed(ctx context.Context, userID int32, limit, offset int32) ([]repo.GetFeedRow, error) {
-	return s.repo.GetFeed(ctx, repo.GetFeedParams{
-		FollowerID: userID,
-		Limit:      limit,
-		Offset:     offset,
+// GetExplore returns the latest explore snapshot, without the posts of
+// authors the viewer blocked, muted or is blocked by. Cursors stay on the
+// snapshot of the first page, so pages do not shift when it is recomputed.
+func (s *svc) GetExplore(ctx context.Context, viewerID int32, p pagination.Params) (pagination.Page[ExploreItem], error) {
+	rows, err := s.repo.ListExplorePosts(ctx, repo.ListExplorePostsParams{
+		CursorTime:  p.CursorTime(),
+		ViewerID:    viewerID,
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[ExploreItem]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.ListExplorePostsRow) pagination.Key {
+		return pagination.Key{Time: r.ComputedAt.Time, Score: r.Score, ID: r.Post.ID}
+	})
+
+	posts := make([]repo.Post, len(rows))
+	ids := make([]int32, len(rows))
+	for i, row := range rows {
+		posts[i] = row.Post
+		ids[i] = row.Post.ID
+	}
+	s.impressions.Record(viewerID, ids...)
+
+	hydrated, err := s.posts.ToResponses(ctx, posts, viewerID)
+	if err != nil {
+		return pagination.Page[ExploreItem]{}, err
+	}
+
+	items := make([]ExploreItem, len(rows))
+	for i, row := range rows {
+		items[i] = ExploreItem{
+			PostResponse:  hydrated[i],
+			LikesCount:    row.LikesCount,
+			CommentsCount: row.CommentsCount,
+			Score:         row.Score,
+		}
+	}
+	return pagination.NewPage(items, c), nil
+}
+
+func (s *svc) ListRankings(ctx context.Context) ([]repo.FeedRankingWeight, error) {
+	return s.repo.ListFeedRankingWeights(ctx)
+}
+
+// UpdateRanking replaces the weights of a ranking. Feeds pick them up on the
+// next request.
+func (s *svc) UpdateRanking(ctx context.Context, ranking string, w Weights) (repo.FeedRankingWeight, error) {
+	for _, v := range []float64{w.Likes, w.Comments, w.Age, w.Gravity} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return repo.FeedRankingWeight{}, ErrInvalidWeights
+		}
+	}
+
+	rw, err := s.repo.UpdateFeedRankingWeights(ctx, repo.UpdateFeedRankingWeightsParams{
+		Ranking:  ranking,
+		Likes:    w.Likes,
+		Comments: w.Comments,
+		Age:      w.Age,
+		Gravity:  w.Gravity,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repo.FeedRankingWeight{}, ErrRankingNotFound
+	}
+	if err != nil {
+		return repo.FeedRankingWeight{}, err
+	}
+	return rw, nil
 }
@@ -0,0 +1,307 @@
+package feed
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/internal/user"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeQuerier serves the feed queries from memory. Other methods of the
+// embedded interface panic when called.
+type fakeQuerier struct {
+	repo.Querier
+	rows     []repo.GetFeedRow
+	ranking  string
+	weights  []repo.FeedRankingWeight
+	feedArgs []repo.GetFeedParams
+}
+
+// GetFeed scores q.rows with the ranking and weights in arg and pages them by
+// score, the way the query does.
+func (q *fakeQuerier) GetFeed(_ context.Context, arg repo.GetFeedParams) ([]repo.GetFeedRow, error) {
+	q.feedArgs = append(q.feedArgs, arg)
+
+	r, err := NewRanker(arg.Ranking, Weights{
+		Likes:    arg.LikesWeight,
+		Comments: arg.CommentsWeight,
+		Age:      arg.AgeWeight,
+		Gravity:  arg.Gravity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := slices.Clone(q.rows)
+	for i, row := range rows {
+		rows[i].Score = r.Score(Candidate{
+			ActiveAt: row.ActiveAt.Time,
+			Likes:    row.LikesCount,
+			Comments: row.CommentsCount,
+		}, arg.AsOf.Time)
+	}
+	slices.SortFunc(rows, func(a, b repo.GetFeedRow) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Post.ID, a.Post.ID)
+	})
+	if arg.Backward {
+		slices.Reverse(rows)
+	}
+
+	if arg.CursorScore.Valid {
+		rows = slices.DeleteFunc(rows, func(r repo.GetFeedRow) bool {
+			c := cmp.Or(cmp.Compare(r.Score, arg.CursorScore.Float64), cmp.Compare(r.Post.ID, arg.CursorID))
+			if arg.Backward {
+				return c <= 0
+			}
+			return c >= 0
+		})
+	}
+
+	rows = rows[min(int(arg.Offset), len(rows)):]
+	return rows[:min(int(arg.Limit), len(rows))], nil
+}
+
+func (q *fakeQuerier) FindUserFeedRankingByID(context.Context, int32) (string, error) {
+	if q.ranking == "" {
+		return "", errors.New("no rows in result set")
+	}
+	return q.ranking, nil
+}
+
+func (q *fakeQuerier) ListFeedRankingWeights(context.Context) ([]repo.FeedRankingWeight, error) {
+	return q.weights, nil
+}
+
+type fakePosts struct {
+	post.Service
+}
+
+func (fakePosts) ToResponses(_ context.Context, posts []repo.Post, _ int32) ([]post.PostResponse, error) {
+	res := make([]post.PostResponse, len(posts))
+	for i, p := range posts {
+		res[i] = post.PostResponse{Post: p}
+	}
+	return res, nil
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(int32, ...int32) {}
+
+// feedRow returns a post that was active h hours before now, which is when
+// the first page of a feed is scored. The time has no monotonic reading, like
+// one read from Postgres, so ages are the same before and after asOf makes a
+// round trip through a cursor.
+func feedRow(id int32, h float64, likes, comments int64) repo.GetFeedRow {
+	activeAt := time.Now().Round(0).Add(-time.Duration(h * float64(time.Hour)))
+	return repo.GetFeedRow{
+		Post:          repo.Post{ID: id},
+		ActiveAt:      pgtype.Timestamptz{Time: activeAt, Valid: true},
+		LikesCount:    likes,
+		CommentsCount: comments,
+	}
+}
+
+func newTestService(q *fakeQuerier) Service {
+	return NewService(q, fakePosts{}, nopRecorder{})
+}
+
+func ids(items []FeedItem) []int32 {
+	res := make([]int32, len(items))
+	for i, item := range items {
+		res[i] = item.ID
+	}
+	return res
+}
+
+// testRows rank 1, 2, 3 by every ranking.
+var testRows = []repo.GetFeedRow{
+	feedRow(1, 1, 10, 2),
+	feedRow(2, 2, 5, 1),
+	feedRow(3, 3, 0, 0),
+}
+
+func TestGetFeedRanking(t *testing.T) {
+	stored := []repo.FeedRankingWeight{{Ranking: user.FeedWeighted, Likes: 1, Comments: 3, Age: 0.5}}
+
+	tests := []struct {
+		name        string
+		setting     string
+		ranking     string
+		wantRanking string
+		wantWeights Weights
+	}{
+		{"setting", user.FeedChronological, "", user.FeedChronological, Weights{}},
+		{"setting weighted", user.FeedWeighted, "", user.FeedWeighted, Weights{Likes: 1, Comments: 3, Age: 0.5}},
+		{"override", user.FeedWeighted, user.FeedChronological, user.FeedChronological, Weights{}},
+		{"default weights", user.FeedChronological, user.FeedDecayed, user.FeedDecayed, DefaultWeights[user.FeedDecayed]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeQuerier{rows: testRows, ranking: tt.setting, weights: stored}
+
+			page, err := newTestService(q).GetFeed(context.Background(), 1, tt.ranking, pagination.Params{Limit: 10})
+			if err != nil {
+				t.Fatalf("GetFeed: %v", err)
+			}
+			if got, want := ids(page.Data), []int32{1, 2, 3}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+
+			arg := q.feedArgs[0]
+			if arg.Ranking != tt.wantRanking {
+				t.Errorf("ranking %q, want %q", arg.Ranking, tt.wantRanking)
+			}
+			got := Weights{Likes: arg.LikesWeight, Comments: arg.CommentsWeight, Age: arg.AgeWeight, Gravity: arg.Gravity}
+			if got != tt.wantWeights {
+				t.Errorf("weights %+v, want %+v", got, tt.wantWeights)
+			}
+		})
+	}
+}
+
+func TestGetFeedOrder(t *testing.T) {
+	// old is popular but a day old, fresh is new and quiet, and middle is in
+	// between on both.
+	old := feedRow(1, 30, 40, 10)
+	fresh := feedRow(2, 1, 1, 0)
+	middle := feedRow(3, 5, 6, 2)
+
+	tests := []struct {
+		ranking string
+		want    []int32
+	}{
+		{user.FeedChronological, []int32{2, 3, 1}},
+		{user.FeedWeighted, []int32{1, 3, 2}},
+		{user.FeedDecayed, []int32{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ranking, func(t *testing.T) {
+			q := &fakeQuerier{rows: []repo.GetFeedRow{old, fresh, middle}}
+
+			page, err := newTestService(q).GetFeed(context.Background(), 1, tt.ranking, pagination.Params{Limit: 10})
+			if err != nil {
+				t.Fatalf("GetFeed: %v", err)
+			}
+			if got := ids(page.Data); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFeedGravity(t *testing.T) {
+	// With enough gravity, a day of age outweighs ten times the engagement.
+	old := feedRow(1, 24, 100, 0)
+	fresh := feedRow(2, 1, 10, 0)
+
+	tests := []struct {
+		name    string
+		gravity float64
+		want    []int32
+	}{
+		{"low", 0.5, []int32{1, 2}},
+		{"high", 1.8, []int32{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeQuerier{
+				rows:    []repo.GetFeedRow{old, fresh},
+				weights: []repo.FeedRankingWeight{{Ranking: user.FeedDecayed, Likes: 1, Gravity: tt.gravity}},
+			}
+
+			page, err := newTestService(q).GetFeed(context.Background(), 1, user.FeedDecayed, pagination.Params{Limit: 10})
+			if err != nil {
+				t.Fatalf("GetFeed: %v", err)
+			}
+			if got := ids(page.Data); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFeedErrors(t *testing.T) {
+	s := newTestService(&fakeQuerier{rows: testRows})
+
+	if _, err := s.GetFeed(context.Background(), 1, "popular", pagination.Params{Limit: 10}); err != ErrInvalidRanking {
+		t.Errorf("invalid ranking: got %v, want ErrInvalidRanking", err)
+	}
+	if _, err := s.GetFeed(context.Background(), 1, "", pagination.Params{Limit: 10}); err != ErrUserNotFound {
+		t.Errorf("missing user: got %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestGetFeedCursors(t *testing.T) {
+	var rows []repo.GetFeedRow
+	for i := range int32(25) {
+		// Pairs of posts share a score, so ties are broken by id.
+		rows = append(rows, feedRow(i+1, 1, int64(i/2), 0))
+	}
+	q := &fakeQuerier{rows: rows, ranking: user.FeedWeighted}
+	s := newTestService(q)
+
+	var seen []int32
+	var pages []pagination.Page[FeedItem]
+	p := pagination.Params{Limit: 10}
+	for {
+		page, err := s.GetFeed(context.Background(), 1, "", p)
+		if err != nil {
+			t.Fatalf("GetFeed: %v", err)
+		}
+		pages = append(pages, page)
+		seen = append(seen, ids(page.Data)...)
+		if page.NextCursor == "" {
+			break
+		}
+
+		c, err := pagination.DecodeCursor(page.NextCursor)
+		if err != nil {
+			t.Fatalf("DecodeCursor: %v", err)
+		}
+		p = pagination.Params{Limit: 10, Cursor: &c}
+	}
+
+	if len(pages) != 3 {
+		t.Fatalf("got %d pages, want 3", len(pages))
+	}
+
+	all, err := s.GetFeed(context.Background(), 1, "", pagination.Params{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+	if want := ids(all.Data); !slices.Equal(seen, want) {
+		t.Errorf("paged through %v, want %v", seen, want)
+	}
+
+	// Every page is scored as of the first.
+	for _, arg := range q.feedArgs[1:len(pages)] {
+		if !arg.AsOf.Time.Equal(q.feedArgs[0].AsOf.Time) {
+			t.Errorf("page scored as of %v, want %v", arg.AsOf.Time, q.feedArgs[0].AsOf.Time)
+		}
+	}
+
+	// Going back from the last page returns the second.
+	c, err := pagination.DecodeCursor(pages[2].PrevCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	prev, err := s.GetFeed(context.Background(), 1, "", pagination.Params{Limit: 10, Cursor: &c})
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+	if got, want := ids(prev.Data), ids(pages[1].Data); !slices.Equal(got, want) {
+		t.Errorf("previous page %v, want %v", got, want)
+	}
+}
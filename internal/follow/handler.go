@@ -36,6 +36,8 @@ func (h *Handler) FollowUser(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "cannot follow yourself", http.StatusBadRequest)
 		case ErrAlreadyFollowing:
 			http.Error(w, "already following this user", http.StatusConflict)
+		case ErrBlocked:
+			http.Error(w, "cannot follow this user", http.StatusForbidden)
 		default:
 			slog.Error("failed to follow user", "error", err)
 			http.Error(w, "failed to follow user", http.StatusInternalServerError)
@@ -79,9 +81,13 @@ func (h *Handler) ListFollowers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	followers, err := h.service.ListFollowers(r.Context(), int32(userID), p.Limit, p.Offset)
+	followers, err := h.service.ListFollowers(r.Context(), int32(userID), p)
 	if err != nil {
 		slog.Error("failed to list followers", "error", err, "user_id", userID)
 		http.Error(w, "failed to list followers", http.StatusInternalServerError)
@@ -99,9 +105,13 @@ func (h *Handler) ListFollowing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	following, err := h.service.ListFollowing(r.Context(), int32(userID), p.Limit, p.Offset)
+	following, err := h.service.ListFollowing(r.Context(), int32(userID), p)
 	if err != nil {
 		slog.Error("failed to list following", "error", err, "user_id", userID)
 		http.Error(w, "failed to list following", http.StatusInternalServerError)
@@ -3,21 +3,27 @@ package follow
 import (
 	"context"
 	"errors"
+	"time"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/feed"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
 	ErrAlreadyFollowing = errors.New("already following this user")
 	ErrSelfFollow       = errors.New("cannot follow yourself")
+	ErrBlocked          = errors.New("cannot follow this user")
 	ErrUserNotFound     = errors.New("user not found")
 )
 
 type Service interface {
 	FollowUser(ctx context.Context, followerID, followingID int32) (repo.Follow, error)
 	UnfollowUser(ctx context.Context, followerID, followingID int32) error
-	ListFollowers(ctx context.Context, userID int32, limit, offset int32) ([]repo.Follow, error)
-	ListFollowing(ctx context.Context, userID int32, limit, offset int32) ([]repo.Follow, error)
+	ListFollowers(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Follow], error)
+	ListFollowing(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Follow], error)
 }
 
 type svc struct {
@@ -28,21 +34,44 @@ func NewService(repo repo.Querier) Service {
 	return &svc{repo: repo}
 }
 
+// FollowUser follows a user and adds their recent posts to the follower's
+// timeline in the same statement.
 func (s *svc) FollowUser(ctx context.Context, followerID, followingID int32) (repo.Follow, error) {
 	if followerID == followingID {
 		return repo.Follow{}, ErrSelfFollow
 	}
 
+	blocked, err := s.repo.IsBlockedEitherWay(ctx, repo.IsBlockedEitherWayParams{
+		BlockerID: followerID,
+		BlockedID: followingID,
+	})
+	if err != nil {
+		return repo.Follow{}, err
+	}
+	if blocked {
+		return repo.Follow{}, ErrBlocked
+	}
+
 	f, err := s.repo.FollowUser(ctx, repo.FollowUserParams{
 		FollowerID:  followerID,
 		FollowingID: followingID,
+		Since:       pgtype.Timestamptz{Time: time.Now().Add(-feed.TimelineRetention), Valid: true},
+		Limit:       feed.BackfillSize,
 	})
 	if err != nil {
-		return repo.Follow{}, ErrAlreadyFollowing
+		switch {
+		case database.IsUniqueViolation(err):
+			return repo.Follow{}, ErrAlreadyFollowing
+		case database.IsForeignKeyViolation(err):
+			return repo.Follow{}, ErrUserNotFound
+		}
+		return repo.Follow{}, err
 	}
 	return f, nil
 }
 
+// UnfollowUser unfollows a user and removes their posts from the follower's
+// timeline.
 func (s *svc) UnfollowUser(ctx context.Context, followerID, followingID int32) error {
 	_, err := s.repo.FindUserByID(ctx, followingID)
 	if err != nil {
@@ -55,18 +84,40 @@ func (s *svc) UnfollowUser(ctx context.Context, followerID, followingID int32) e
 	})
 }
 
-func (s *svc) ListFollowers(ctx context.Context, userID int32, limit, offset int32) ([]repo.Follow, error) {
-	return s.repo.ListFollowers(ctx, repo.ListFollowersParams{
+func (s *svc) ListFollowers(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Follow], error) {
+	follows, err := s.repo.ListFollowers(ctx, repo.ListFollowersParams{
 		FollowingID: userID,
-		Limit:       limit,
-		Offset:      offset,
+		CursorTime:  p.CursorTime(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
 	})
+	if err != nil {
+		return pagination.Page[repo.Follow]{}, err
+	}
+
+	follows, c := pagination.Paginate(follows, p, func(f repo.Follow) pagination.Key {
+		return pagination.Key{Time: f.CreatedAt.Time, ID: f.ID}
+	})
+	return pagination.NewPage(follows, c), nil
 }
 
-func (s *svc) ListFollowing(ctx context.Context, userID int32, limit, offset int32) ([]repo.Follow, error) {
-	return s.repo.ListFollowing(ctx, repo.ListFollowingParams{
+func (s *svc) ListFollowing(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Follow], error) {
+	follows, err := s.repo.ListFollowing(ctx, repo.ListFollowingParams{
 		FollowerID: userID,
-		Limit:      limit,
-		Offset:     offset,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Follow]{}, err
+	}
+
+	follows, c := pagination.Paginate(follows, p, func(f repo.Follow) pagination.Key {
+		return pagination.Key{Time: f.CreatedAt.Time, ID: f.ID}
 	})
+	return pagination.NewPage(follows, c), nil
 }
@@ -0,0 +1,8 @@
+package hashtag
+
+type TrendingHashtag struct {
+	Name         string `json:"name"`
+	RecentUses   int64  `json:"recent_uses"`
+	PreviousUses int64  `json:"previous_uses"`
+	Velocity     int64  `json:"velocity"`
+}
@@ -0,0 +1,59 @@
+package hashtag
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) ListPosts(w http.ResponseWriter, r *http.Request) {
+	tag := chi.URLParam(r, "tag")
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	posts, err := h.service.ListPosts(r.Context(), tag, auth.UserIDFromContext(r.Context()), p)
+	if err != nil {
+		switch err {
+		case ErrInvalidHashtag:
+			http.Error(w, "invalid hashtag", http.StatusBadRequest)
+		default:
+			slog.Error("failed to list hashtag posts", "error", err, "tag", tag)
+			http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, posts)
+}
+
+func (h *Handler) ListTrending(w http.ResponseWriter, r *http.Request) {
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	trending, err := h.service.ListTrending(r.Context(), p.Limit)
+	if err != nil {
+		slog.Error("failed to list trending hashtags", "error", err)
+		http.Error(w, "failed to list trending hashtags", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, trending)
+}
@@ -0,0 +1,98 @@
+package hashtag
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+	tags "github.com/etherealsense/social-network/pkg/hashtag"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// TrendingWindow is the sliding window trending tags are measured over.
+	// Uses in the window are compared with the window right before it, so a
+	// tag trends when it picks up speed, not because it is always popular.
+	TrendingWindow = 24 * time.Hour
+	// MinTrendingUses is how many distinct authors must use a tag inside the
+	// window before it can trend.
+	MinTrendingUses = 3
+)
+
+var ErrInvalidHashtag = errors.New("invalid hashtag")
+
+type Service interface {
+	ListPosts(ctx context.Context, tag string, viewerID int32, p pagination.Params) (pagination.Page[post.PostResponse], error)
+	ListTrending(ctx context.Context, limit int32) ([]TrendingHashtag, error)
+}
+
+type svc struct {
+	repo  repo.Querier
+	posts post.Service
+}
+
+func NewService(repo repo.Querier, posts post.Service) Service {
+	return &svc{repo: repo, posts: posts}
+}
+
+// ListPosts lists the posts tagged with tag, most recently published first.
+func (s *svc) ListPosts(ctx context.Context, tag string, viewerID int32, p pagination.Params) (pagination.Page[post.PostResponse], error) {
+	name := tags.Normalize(tag)
+	if name == "" {
+		return pagination.Page[post.PostResponse]{}, ErrInvalidHashtag
+	}
+
+	posts, err := s.repo.ListPostsByHashtag(ctx, repo.ListPostsByHashtagParams{
+		Name:       name,
+		ViewerID:   viewerID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[post.PostResponse]{}, err
+	}
+
+	posts, c := pagination.Paginate(posts, p, func(p repo.Post) pagination.Key {
+		published := p.CreatedAt.Time
+		if p.PublishAt.Valid {
+			published = p.PublishAt.Time
+		}
+		return pagination.Key{Time: published, ID: p.ID}
+	})
+
+	res, err := s.posts.ToResponses(ctx, posts, viewerID)
+	if err != nil {
+		return pagination.Page[post.PostResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+func (s *svc) ListTrending(ctx context.Context, limit int32) ([]TrendingHashtag, error) {
+	now := time.Now()
+	rows, err := s.repo.ListTrendingHashtags(ctx, repo.ListTrendingHashtagsParams{
+		WindowStart:   pgtype.Timestamptz{Time: now.Add(-TrendingWindow), Valid: true},
+		PreviousStart: pgtype.Timestamptz{Time: now.Add(-2 * TrendingWindow), Valid: true},
+		MinUses:       MinTrendingUses,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trending := make([]TrendingHashtag, len(rows))
+	for i, row := range rows {
+		trending[i] = TrendingHashtag{
+			Name:         row.Name,
+			RecentUses:   row.RecentUses,
+			PreviousUses: row.PreviousUses,
+			Velocity:     row.RecentUses - row.PreviousUses,
+		}
+	}
+	return trending, nil
+}
@@ -0,0 +1,145 @@
+package impression
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// Window is the period in which repeated views of a post by the same
+	// viewer count once.
+	Window = 24 * time.Hour
+
+	// MaxPending bounds the memory used between flushes. Impressions past it
+	// are dropped until the next flush.
+	MaxPending = 100_000
+
+	flushTimeout = 10 * time.Second
+)
+
+// Recorder records that a viewer was shown posts.
+type Recorder interface {
+	Record(viewerID int32, postIDs ...int32)
+}
+
+type key struct {
+	postID      int32
+	viewerID    int32
+	windowStart time.Time
+}
+
+// Buffer collects impressions in memory and writes them to Postgres in
+// batches, so that reading posts does not write to the database. Duplicates
+// within a window are merged in memory and again by the primary key of
+// post_impressions, so several API instances may flush the same views.
+type Buffer struct {
+	repo     repo.Querier
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[key]struct{}
+}
+
+func NewBuffer(repo repo.Querier, interval time.Duration) *Buffer {
+	return &Buffer{
+		repo:     repo,
+		interval: interval,
+		pending:  make(map[key]struct{}),
+	}
+}
+
+// Record buffers one impression per post. Anonymous viewers cannot be told
+// apart and are not counted.
+func (b *Buffer) Record(viewerID int32, postIDs ...int32) {
+	if viewerID == 0 {
+		return
+	}
+
+	windowStart := time.Now().UTC().Truncate(Window)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, id := range postIDs {
+		if len(b.pending) >= MaxPending {
+			return
+		}
+		b.pending[key{postID: id, viewerID: viewerID, windowStart: windowStart}] = struct{}{}
+	}
+}
+
+// Run flushes the buffer every interval, and once more when ctx is done so
+// that impressions are not lost on shutdown.
+func (b *Buffer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			b.flush(ctx)
+			cancel()
+			return
+		case <-ticker.C:
+			b.flush(ctx)
+		}
+	}
+}
+
+func (b *Buffer) flush(ctx context.Context) {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[key]struct{})
+	b.mu.Unlock()
+
+	if len(pending) > 0 {
+		params := repo.RecordImpressionsParams{
+			PostIds:      make([]int32, 0, len(pending)),
+			ViewerIds:    make([]int32, 0, len(pending)),
+			WindowStarts: make([]pgtype.Timestamptz, 0, len(pending)),
+		}
+		for k := range pending {
+			params.PostIds = append(params.PostIds, k.postID)
+			params.ViewerIds = append(params.ViewerIds, k.viewerID)
+			params.WindowStarts = append(params.WindowStarts, pgtype.Timestamptz{Time: k.windowStart, Valid: true})
+		}
+
+		if err := b.repo.RecordImpressions(ctx, params); err != nil {
+			dropped := b.requeue(pending)
+			slog.Error("failed to record impressions", "error", err, "count", len(pending), "dropped", dropped)
+			return
+		}
+	}
+
+	// Impressions of past windows are no longer needed for deduplication.
+	before := pgtype.Timestamptz{Time: time.Now().UTC().Truncate(Window), Valid: true}
+	if _, err := b.repo.DeleteExpiredImpressions(ctx, before); err != nil {
+		slog.Error("failed to delete expired impressions", "error", err)
+	}
+}
+
+// requeue puts a batch that failed to flush back into the buffer for the next
+// flush, and returns how many impressions did not fit under MaxPending.
+func (b *Buffer) requeue(batch map[key]struct{}) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dropped := 0
+	for k := range batch {
+		if _, ok := b.pending[k]; ok {
+			continue
+		}
+		if len(b.pending) >= MaxPending {
+			dropped++
+			continue
+		}
+		b.pending[k] = struct{}{}
+	}
+	return dropped
+}
@@ -0,0 +1,11 @@
+package like
+
+import (
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type LikedPostResponse struct {
+	LikedAt pgtype.Timestamptz `json:"liked_at"`
+	Post    post.PostResponse  `json:"post"`
+}
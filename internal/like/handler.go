@@ -79,14 +79,106 @@ func (h *Handler) ListLikesByPostID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	likes, err := h.service.ListLikesByPostID(r.Context(), int32(postID), p.Limit, p.Offset)
+	likes, err := h.service.ListLikesByPostID(r.Context(), int32(postID), auth.UserIDFromContext(r.Context()), p)
 	if err != nil {
-		slog.Error("failed to list likes", "error", err, "post_id", postID)
-		http.Error(w, "failed to list likes", http.StatusInternalServerError)
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to list likes", "error", err, "post_id", postID)
+			http.Error(w, "failed to list likes", http.StatusInternalServerError)
+		}
 		return
 	}
 
 	json.Write(w, http.StatusOK, likes)
 }
+
+func (h *Handler) ListLikedPosts(w http.ResponseWriter, r *http.Request) {
+	userIDStr := chi.URLParam(r, "user_id")
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListLikedPosts(r.Context(), int32(userID), auth.UserIDFromContext(r.Context()), p)
+	if err != nil {
+		switch err {
+		case ErrUserNotFound:
+			http.Error(w, "user not found", http.StatusNotFound)
+		case ErrLikesHidden:
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			slog.Error("failed to list liked posts", "error", err, "user_id", userID)
+			http.Error(w, "failed to list liked posts", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, page)
+}
+
+func (h *Handler) LikeComment(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.service.LikeComment(r.Context(), uid, int32(id))
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		case ErrAlreadyLikedComment:
+			http.Error(w, "already liked this comment", http.StatusConflict)
+		default:
+			slog.Error("failed to like comment", "error", err, "comment_id", id)
+			http.Error(w, "failed to like comment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, l)
+}
+
+func (h *Handler) UnlikeComment(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.UnlikeComment(r.Context(), uid, int32(id))
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to unlike comment", "error", err, "comment_id", id)
+			http.Error(w, "failed to unlike comment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,31 +5,44 @@ import (
 	"errors"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5"
 )
 
 var (
-	ErrAlreadyLiked = errors.New("already liked this post")
-	ErrPostNotFound = errors.New("post not found")
+	ErrAlreadyLiked        = errors.New("already liked this post")
+	ErrAlreadyLikedComment = errors.New("already liked this comment")
+	ErrPostNotFound        = errors.New("post not found")
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrLikesHidden         = errors.New("this user's likes are private")
 )
 
 type Service interface {
 	LikePost(ctx context.Context, userID, postID int32) (repo.Like, error)
 	UnlikePost(ctx context.Context, userID, postID int32) error
-	ListLikesByPostID(ctx context.Context, postID int32, limit, offset int32) ([]repo.Like, error)
+	ListLikesByPostID(ctx context.Context, postID, viewerID int32, p pagination.Params) (pagination.Page[repo.Like], error)
+	ListLikedPosts(ctx context.Context, userID, viewerID int32, p pagination.Params) (pagination.Page[LikedPostResponse], error)
+	LikeComment(ctx context.Context, userID, commentID int32) (repo.CommentLike, error)
+	UnlikeComment(ctx context.Context, userID, commentID int32) error
 }
 
 type svc struct {
-	repo repo.Querier
+	repo  repo.Querier
+	posts post.Service
 }
 
-func NewService(repo repo.Querier) Service {
-	return &svc{repo: repo}
+func NewService(repo repo.Querier, posts post.Service) Service {
+	return &svc{repo: repo, posts: posts}
 }
 
+// LikePost likes a post the user can read, replacing another reaction the
+// user left on it. Liking a post twice returns ErrAlreadyLiked.
 func (s *svc) LikePost(ctx context.Context, userID, postID int32) (repo.Like, error) {
-	_, err := s.repo.FindPostByID(ctx, postID)
-	if err != nil {
-		return repo.Like{}, ErrPostNotFound
+	if err := s.checkVisible(ctx, postID, userID); err != nil {
+		return repo.Like{}, err
 	}
 
 	l, err := s.repo.LikePost(ctx, repo.LikePostParams{
@@ -37,7 +50,13 @@ func (s *svc) LikePost(ctx context.Context, userID, postID int32) (repo.Like, er
 		PostID: postID,
 	})
 	if err != nil {
-		return repo.Like{}, ErrAlreadyLiked
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return repo.Like{}, ErrAlreadyLiked
+		case database.IsForeignKeyViolation(err):
+			return repo.Like{}, ErrPostNotFound
+		}
+		return repo.Like{}, err
 	}
 	return l, nil
 }
@@ -54,10 +73,126 @@ func (s *svc) UnlikePost(ctx context.Context, userID, postID int32) error {
 	})
 }
 
-func (s *svc) ListLikesByPostID(ctx context.Context, postID int32, limit, offset int32) ([]repo.Like, error) {
-	return s.repo.ListLikesByPostID(ctx, repo.ListLikesByPostIDParams{
-		PostID: postID,
-		Limit:  limit,
-		Offset: offset,
+func (s *svc) ListLikesByPostID(ctx context.Context, postID, viewerID int32, p pagination.Params) (pagination.Page[repo.Like], error) {
+	if err := s.checkVisible(ctx, postID, viewerID); err != nil {
+		return pagination.Page[repo.Like]{}, err
+	}
+
+	likes, err := s.repo.ListLikesByPostID(ctx, repo.ListLikesByPostIDParams{
+		PostID:     postID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Like]{}, err
+	}
+
+	likes, c := pagination.Paginate(likes, p, func(l repo.Like) pagination.Key {
+		return pagination.Key{Time: l.CreatedAt.Time, ID: l.ID}
+	})
+	return pagination.NewPage(likes, c), nil
+}
+
+// ListLikedPosts returns the posts userID has liked that viewerID can read,
+// most recently liked first. Users who hide their likes only see them
+// themselves.
+func (s *svc) ListLikedPosts(ctx context.Context, userID, viewerID int32, p pagination.Params) (pagination.Page[LikedPostResponse], error) {
+	hidden, err := s.repo.FindUserHideLikesByID(ctx, userID)
+	if err != nil {
+		return pagination.Page[LikedPostResponse]{}, ErrUserNotFound
+	}
+
+	if hidden && userID != viewerID {
+		return pagination.Page[LikedPostResponse]{}, ErrLikesHidden
+	}
+
+	rows, err := s.repo.ListLikedPostsByUserID(ctx, repo.ListLikedPostsByUserIDParams{
+		UserID:   userID,
+		ViewerID: viewerID,
+		CursorID: p.CursorID(),
+		Backward: p.Backward(),
+		Limit:    p.Fetch(),
+		Offset:   p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[LikedPostResponse]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.ListLikedPostsByUserIDRow) pagination.Key {
+		return pagination.Key{ID: r.LikeID}
+	})
+
+	posts := make([]repo.Post, len(rows))
+	for i, row := range rows {
+		posts[i] = row.Post
+	}
+
+	hydrated, err := s.posts.ToResponses(ctx, posts, viewerID)
+	if err != nil {
+		return pagination.Page[LikedPostResponse]{}, err
+	}
+
+	liked := make([]LikedPostResponse, len(rows))
+	for i, row := range rows {
+		liked[i] = LikedPostResponse{
+			LikedAt: row.LikedAt,
+			Post:    hydrated[i],
+		}
+	}
+	return pagination.NewPage(liked, c), nil
+}
+
+// LikeComment likes a comment on a post the user can read, replacing another
+// reaction the user left on it. Liking a comment twice returns
+// ErrAlreadyLikedComment.
+func (s *svc) LikeComment(ctx context.Context, userID, commentID int32) (repo.CommentLike, error) {
+	c, err := s.repo.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return repo.CommentLike{}, ErrCommentNotFound
+	}
+
+	if err := s.checkVisible(ctx, c.PostID, userID); err != nil {
+		return repo.CommentLike{}, ErrCommentNotFound
+	}
+
+	l, err := s.repo.LikeComment(ctx, repo.LikeCommentParams{
+		UserID:    userID,
+		CommentID: commentID,
 	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return repo.CommentLike{}, ErrAlreadyLikedComment
+		case database.IsForeignKeyViolation(err):
+			return repo.CommentLike{}, ErrCommentNotFound
+		}
+		return repo.CommentLike{}, err
+	}
+	return l, nil
+}
+
+func (s *svc) UnlikeComment(ctx context.Context, userID, commentID int32) error {
+	_, err := s.repo.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+
+	return s.repo.UnlikeComment(ctx, repo.UnlikeCommentParams{
+		UserID:    userID,
+		CommentID: commentID,
+	})
+}
+
+func (s *svc) checkVisible(ctx context.Context, postID, viewerID int32) error {
+	p, err := s.repo.FindVisiblePostByID(ctx, repo.FindVisiblePostByIDParams{
+		ID:       postID,
+		ViewerID: viewerID,
+	})
+	if err != nil || p.Status != post.StatusPublished {
+		return ErrPostNotFound
+	}
+	return nil
 }
@@ -0,0 +1,72 @@
+package media
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// orphanTTL is how long an upload may stay unattached before it is
+	// considered abandoned.
+	orphanTTL          = 24 * time.Hour
+	collectorBatchSize = 100
+)
+
+// Collector garbage-collects uploads that were never attached to a post, or
+// whose post has since been purged.
+type Collector struct {
+	repo     repo.Querier
+	storage  Storage
+	interval time.Duration
+}
+
+func NewCollector(repo repo.Querier, storage Storage, interval time.Duration) *Collector {
+	return &Collector{repo: repo, storage: storage, interval: interval}
+}
+
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.collect(ctx)
+		}
+	}
+}
+
+func (c *Collector) collect(ctx context.Context) {
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-orphanTTL), Valid: true}
+
+	for {
+		attachments, err := c.repo.DeleteOrphanedAttachments(ctx, repo.DeleteOrphanedAttachmentsParams{
+			CreatedAt: cutoff,
+			Limit:     collectorBatchSize,
+		})
+		if err != nil {
+			slog.Error("failed to delete orphaned attachments", "error", err)
+			return
+		}
+
+		for _, a := range attachments {
+			if err := c.storage.Delete(ctx, a.StorageKey); err != nil {
+				slog.Error("failed to delete attachment file", "error", err, "key", a.StorageKey)
+			}
+		}
+
+		if len(attachments) > 0 {
+			slog.Info("collected orphaned attachments", "count", len(attachments))
+		}
+
+		if len(attachments) < collectorBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package media
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type UpdateAttachmentRequest struct {
+	AltText string `json:"alt_text"`
+}
+
+type AttachmentResponse struct {
+	ID       int32       `json:"id"`
+	Kind     string      `json:"kind"`
+	MimeType string      `json:"mime_type"`
+	URL      string      `json:"url"`
+	Width    pgtype.Int4 `json:"width"`
+	Height   pgtype.Int4 `json:"height"`
+	Blurhash pgtype.Text `json:"blurhash"`
+	AltText  string      `json:"alt_text"`
+}
@@ -0,0 +1,107 @@
+package media
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxUploadBody leaves room for the multipart envelope and the alt text on
+// top of the largest allowed file.
+const maxUploadBody = MaxVideoSize + 1<<20
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	// Large uploads on slow connections outlive the server-wide timeouts.
+	deadline := time.Now().Add(5 * time.Minute)
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		slog.Warn("failed to extend upload read deadline", "error", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		slog.Warn("failed to extend upload write deadline", "error", err)
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBody)
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, "file too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.Upload(r.Context(), uid, file, r.FormValue("alt_text"))
+	if err != nil {
+		switch err {
+		case ErrUnsupportedType:
+			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+		case ErrFileTooLarge:
+			http.Error(w, "file too large", http.StatusRequestEntityTooLarge)
+		case ErrInvalidImage, ErrAltTextTooLong:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to upload attachment", "error", err, "user_id", uid)
+			http.Error(w, "failed to upload attachment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, attachment)
+}
+
+func (h *Handler) UpdateAttachment(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid attachment id", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateAttachmentRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read update attachment request", "error", err)
+		http.Error(w, "failed to read update attachment request", http.StatusBadRequest)
+		return
+	}
+
+	attachment, err := h.service.UpdateAttachment(r.Context(), int32(id), uid, req)
+	if err != nil {
+		switch err {
+		case ErrAttachmentNotFound:
+			http.Error(w, "attachment not found", http.StatusNotFound)
+		case ErrAttachmentForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case ErrAltTextTooLong:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to update attachment", "error", err, "attachment_id", id, "user_id", uid)
+			http.Error(w, "failed to update attachment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, attachment)
+}
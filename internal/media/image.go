@@ -0,0 +1,106 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/buckket/go-blurhash"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	maxImageDimension = 2048
+	// maxImagePixels bounds the memory a decode takes, about 100 MB at four
+	// bytes per pixel.
+	maxImagePixels = 25_000_000
+	blurhashSize   = 32
+)
+
+var ErrInvalidImage = errors.New("invalid image")
+
+type processedImage struct {
+	data     []byte
+	mimeType string
+	width    int
+	height   int
+	blurhash string
+}
+
+// processImage downscales images larger than maxImageDimension and re-encodes
+// them, which also strips EXIF and other metadata. GIFs are kept as uploaded
+// so that animations survive.
+func processImage(data []byte, mimeType string) (processedImage, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxImagePixels {
+		return processedImage{}, ErrInvalidImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return processedImage{}, ErrInvalidImage
+	}
+
+	hash, err := blurhash.Encode(4, 3, resize(img, blurhashSize))
+	if err != nil {
+		return processedImage{}, err
+	}
+
+	if mimeType == "image/gif" {
+		return processedImage{
+			data:     data,
+			mimeType: mimeType,
+			width:    cfg.Width,
+			height:   cfg.Height,
+			blurhash: hash,
+		}, nil
+	}
+
+	img = resize(img, maxImageDimension)
+
+	var buf bytes.Buffer
+	if mimeType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		mimeType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return processedImage{}, err
+	}
+
+	bounds := img.Bounds()
+	return processedImage{
+		data:     buf.Bytes(),
+		mimeType: mimeType,
+		width:    bounds.Dx(),
+		height:   bounds.Dy(),
+		blurhash: hash,
+	}, nil
+}
+
+// resize scales img down so that neither side exceeds maxSide, keeping the
+// aspect ratio. Smaller images are returned unchanged.
+func resize(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxSide && h <= maxSide {
+		return img
+	}
+
+	if w >= h {
+		h = max(1, h*maxSide/w)
+		w = maxSide
+	} else {
+		w = max(1, w*maxSide/h)
+		h = maxSide
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
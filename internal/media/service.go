@@ -0,0 +1,260 @@
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"unicode/utf8"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	KindImage = "image"
+	KindVideo = "video"
+
+	MaxAttachmentsPerPost = 4
+	MaxImageSize          = 10 << 20
+	// MaxVideoSize is the only limit on videos. Their duration is not
+	// probed, so how long a video may be depends on its bitrate.
+	MaxVideoSize = 50 << 20
+	// MaxAltTextLength is counted in characters, not bytes.
+	MaxAltTextLength = 1500
+)
+
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrAttachmentForbidden = errors.New("forbidden")
+	ErrUnsupportedType     = errors.New("unsupported media type")
+	ErrFileTooLarge        = errors.New("file too large")
+	ErrAltTextTooLong      = errors.New("alt text too long")
+	ErrTooManyAttachments  = errors.New("too many attachments")
+	ErrInvalidAttachments  = errors.New("invalid attachments")
+)
+
+// allowedTypes maps sniffed MIME types to the attachment kind and the file
+// extension used for storage keys.
+var allowedTypes = map[string]struct {
+	kind string
+	ext  string
+}{
+	"image/jpeg": {KindImage, ".jpg"},
+	"image/png":  {KindImage, ".png"},
+	"image/gif":  {KindImage, ".gif"},
+	"image/webp": {KindImage, ".webp"},
+	"video/mp4":  {KindVideo, ".mp4"},
+	"video/webm": {KindVideo, ".webm"},
+}
+
+type Service interface {
+	Upload(ctx context.Context, userID int32, file io.Reader, altText string) (AttachmentResponse, error)
+	UpdateAttachment(ctx context.Context, id, userID int32, req UpdateAttachmentRequest) (AttachmentResponse, error)
+	ValidateAttachable(ctx context.Context, userID int32, ids []int32) error
+	Attach(ctx context.Context, userID, postID int32, ids []int32) error
+	ListByPostIDs(ctx context.Context, postIDs []int32) (map[int32][]AttachmentResponse, error)
+}
+
+type svc struct {
+	repo    repo.Querier
+	storage Storage
+}
+
+func NewService(repo repo.Querier, storage Storage) Service {
+	return &svc{repo: repo, storage: storage}
+}
+
+// Upload stores an image or video that is not attached to a post yet. Images
+// are resized and given a blurhash; videos are stored as sent, limited by
+// size only.
+func (s *svc) Upload(ctx context.Context, userID int32, file io.Reader, altText string) (AttachmentResponse, error) {
+	if utf8.RuneCountInString(altText) > MaxAltTextLength {
+		return AttachmentResponse{}, ErrAltTextTooLong
+	}
+
+	br := bufio.NewReaderSize(file, 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return AttachmentResponse{}, err
+	}
+
+	mimeType := http.DetectContentType(head)
+	typ, ok := allowedTypes[mimeType]
+	if !ok {
+		return AttachmentResponse{}, ErrUnsupportedType
+	}
+
+	params := repo.CreateAttachmentParams{
+		UserID:  userID,
+		Kind:    typ.kind,
+		AltText: altText,
+	}
+
+	var body io.Reader
+	switch typ.kind {
+	case KindImage:
+		data, err := io.ReadAll(io.LimitReader(br, MaxImageSize+1))
+		if err != nil {
+			return AttachmentResponse{}, err
+		}
+		if len(data) > MaxImageSize {
+			return AttachmentResponse{}, ErrFileTooLarge
+		}
+
+		img, err := processImage(data, mimeType)
+		if err != nil {
+			return AttachmentResponse{}, err
+		}
+
+		if img.mimeType != mimeType {
+			mimeType = img.mimeType
+			typ = allowedTypes[mimeType]
+		}
+
+		body = bytes.NewReader(img.data)
+		params.Width = pgtype.Int4{Int32: int32(img.width), Valid: true}
+		params.Height = pgtype.Int4{Int32: int32(img.height), Valid: true}
+		params.Blurhash = pgtype.Text{String: img.blurhash, Valid: true}
+	case KindVideo:
+		body = io.LimitReader(br, MaxVideoSize+1)
+	}
+
+	key, err := newKey(typ.ext)
+	if err != nil {
+		return AttachmentResponse{}, err
+	}
+
+	size, err := s.storage.Put(ctx, key, body)
+	if err != nil {
+		return AttachmentResponse{}, err
+	}
+	if size > MaxVideoSize {
+		s.storage.Delete(ctx, key)
+		return AttachmentResponse{}, ErrFileTooLarge
+	}
+
+	params.MimeType = mimeType
+	params.SizeBytes = size
+	params.StorageKey = key
+
+	a, err := s.repo.CreateAttachment(ctx, params)
+	if err != nil {
+		s.storage.Delete(ctx, key)
+		return AttachmentResponse{}, err
+	}
+
+	return s.toResponse(a), nil
+}
+
+func (s *svc) UpdateAttachment(ctx context.Context, id, userID int32, req UpdateAttachmentRequest) (AttachmentResponse, error) {
+	if utf8.RuneCountInString(req.AltText) > MaxAltTextLength {
+		return AttachmentResponse{}, ErrAltTextTooLong
+	}
+
+	a, err := s.repo.FindAttachmentByID(ctx, id)
+	if err != nil {
+		return AttachmentResponse{}, ErrAttachmentNotFound
+	}
+
+	if a.UserID != userID {
+		return AttachmentResponse{}, ErrAttachmentForbidden
+	}
+
+	a, err = s.repo.UpdateAttachmentAltText(ctx, repo.UpdateAttachmentAltTextParams{
+		ID:      id,
+		AltText: req.AltText,
+	})
+	if err != nil {
+		return AttachmentResponse{}, err
+	}
+
+	return s.toResponse(a), nil
+}
+
+// ValidateAttachable checks that every id refers to an upload of userID that
+// is not attached to a post yet.
+func (s *svc) ValidateAttachable(ctx context.Context, userID int32, ids []int32) error {
+	if len(ids) > MaxAttachmentsPerPost {
+		return ErrTooManyAttachments
+	}
+
+	if hasDuplicates(ids) {
+		return ErrInvalidAttachments
+	}
+
+	count, err := s.repo.CountUnattachedAttachments(ctx, repo.CountUnattachedAttachmentsParams{
+		Ids:    ids,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if count != int64(len(ids)) {
+		return ErrInvalidAttachments
+	}
+	return nil
+}
+
+// Attach links the uploads to a post, keeping the order of ids.
+func (s *svc) Attach(ctx context.Context, userID, postID int32, ids []int32) error {
+	n, err := s.repo.AttachToPost(ctx, repo.AttachToPostParams{
+		PostID: postID,
+		Ids:    ids,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if n != int64(len(ids)) {
+		return ErrInvalidAttachments
+	}
+	return nil
+}
+
+func (s *svc) ListByPostIDs(ctx context.Context, postIDs []int32) (map[int32][]AttachmentResponse, error) {
+	attachments, err := s.repo.ListAttachmentsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int32][]AttachmentResponse, len(postIDs))
+	for _, a := range attachments {
+		res[a.PostID.Int32] = append(res[a.PostID.Int32], s.toResponse(a))
+	}
+	return res, nil
+}
+
+func (s *svc) toResponse(a repo.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:       a.ID,
+		Kind:     a.Kind,
+		MimeType: a.MimeType,
+		URL:      s.storage.URL(a.StorageKey),
+		Width:    a.Width,
+		Height:   a.Height,
+		Blurhash: a.Blurhash,
+		AltText:  a.AltText,
+	}
+}
+
+func newKey(ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b) + ext, nil
+}
+
+func hasDuplicates(ids []int32) bool {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return len(slices.Compact(sorted)) != len(ids)
+}
@@ -0,0 +1,71 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage persists uploaded media under opaque keys.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// LocalStorage keeps media on the local disk. Files are expected to be served
+// from baseURL, see cmd/api.go.
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	f, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+
+	n, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return n, err
+	}
+
+	if err := f.Close(); err != nil {
+		return n, err
+	}
+
+	return n, os.Rename(f.Name(), s.path(key))
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.dir, filepath.Base(key))
+}
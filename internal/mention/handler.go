@@ -0,0 +1,36 @@
+package mention
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) ListMentions(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	mentions, err := h.service.ListMentions(r.Context(), uid, p)
+	if err != nil {
+		slog.Error("failed to list mentions", "error", err, "user_id", uid)
+		http.Error(w, "failed to list mentions", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, mentions)
+}
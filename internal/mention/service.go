@@ -0,0 +1,42 @@
+package mention
+
+import (
+	"context"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+type Service interface {
+	ListMentions(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.ListMentionsByUserIDRow], error)
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// ListMentions returns where the user has been mentioned, newest first.
+// Mentions in unpublished or deleted content, or involving a blocked user,
+// are left out.
+func (s *svc) ListMentions(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.ListMentionsByUserIDRow], error) {
+	mentions, err := s.repo.ListMentionsByUserID(ctx, repo.ListMentionsByUserIDParams{
+		UserID:     userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.ListMentionsByUserIDRow]{}, err
+	}
+
+	mentions, c := pagination.Paginate(mentions, p, func(m repo.ListMentionsByUserIDRow) pagination.Key {
+		return pagination.Key{Time: m.CreatedAt.Time, ID: m.ID}
+	})
+	return pagination.NewPage(mentions, c), nil
+}
@@ -0,0 +1,92 @@
+package mute
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) MuteUser(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	mutedIDStr := chi.URLParam(r, "user_id")
+	mutedID, err := strconv.Atoi(mutedIDStr)
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.service.MuteUser(r.Context(), uid, int32(mutedID))
+	if err != nil {
+		switch err {
+		case ErrSelfMute:
+			http.Error(w, "cannot mute yourself", http.StatusBadRequest)
+		case ErrUserNotFound:
+			http.Error(w, "user not found", http.StatusNotFound)
+		case ErrAlreadyMuted:
+			http.Error(w, "already muted this user", http.StatusConflict)
+		default:
+			slog.Error("failed to mute user", "error", err)
+			http.Error(w, "failed to mute user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, m)
+}
+
+func (h *Handler) UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	mutedIDStr := chi.URLParam(r, "user_id")
+	mutedID, err := strconv.Atoi(mutedIDStr)
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.UnmuteUser(r.Context(), uid, int32(mutedID))
+	if err != nil {
+		switch err {
+		case ErrNotMuted:
+			http.Error(w, "user is not muted", http.StatusNotFound)
+		default:
+			slog.Error("failed to unmute user", "error", err)
+			http.Error(w, "failed to unmute user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListMutedUsers(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListMutedUsers(r.Context(), uid, p)
+	if err != nil {
+		slog.Error("failed to list muted users", "error", err, "user_id", uid)
+		http.Error(w, "failed to list muted users", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, page)
+}
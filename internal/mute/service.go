@@ -0,0 +1,88 @@
+package mute
+
+import (
+	"context"
+	"errors"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+var (
+	ErrAlreadyMuted = errors.New("already muted this user")
+	ErrNotMuted     = errors.New("user is not muted")
+	ErrSelfMute     = errors.New("cannot mute yourself")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+type Service interface {
+	MuteUser(ctx context.Context, muterID, mutedID int32) (repo.Mute, error)
+	UnmuteUser(ctx context.Context, muterID, mutedID int32) error
+	ListMutedUsers(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Mute], error)
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// MuteUser hides the posts of a user from the feed, explore feed and search
+// results of the muter, and their comments from search. Unlike a block, the
+// muted user is not told and follows are kept.
+func (s *svc) MuteUser(ctx context.Context, muterID, mutedID int32) (repo.Mute, error) {
+	if muterID == mutedID {
+		return repo.Mute{}, ErrSelfMute
+	}
+
+	m, err := s.repo.MuteUser(ctx, repo.MuteUserParams{
+		MuterID: muterID,
+		MutedID: mutedID,
+	})
+	if err != nil {
+		switch {
+		case database.IsUniqueViolation(err):
+			return repo.Mute{}, ErrAlreadyMuted
+		case database.IsForeignKeyViolation(err):
+			return repo.Mute{}, ErrUserNotFound
+		}
+		return repo.Mute{}, err
+	}
+	return m, nil
+}
+
+func (s *svc) UnmuteUser(ctx context.Context, muterID, mutedID int32) error {
+	n, err := s.repo.UnmuteUser(ctx, repo.UnmuteUserParams{
+		MuterID: muterID,
+		MutedID: mutedID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotMuted
+	}
+	return nil
+}
+
+func (s *svc) ListMutedUsers(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Mute], error) {
+	mutes, err := s.repo.ListMutedUsers(ctx, repo.ListMutedUsersParams{
+		MuterID:    userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Mute]{}, err
+	}
+
+	mutes, c := pagination.Paginate(mutes, p, func(m repo.Mute) pagination.Key {
+		return pagination.Key{Time: m.CreatedAt.Time, ID: m.MutedID}
+	})
+	return pagination.NewPage(mutes, c), nil
+}
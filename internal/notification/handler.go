@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	notifications, err := h.service.ListNotifications(r.Context(), uid, p)
+	if err != nil {
+		slog.Error("failed to list notifications", "error", err, "user_id", uid)
+		http.Error(w, "failed to list notifications", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, notifications)
+}
+
+func (h *Handler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	if err := h.service.MarkAllRead(r.Context(), uid); err != nil {
+		slog.Error("failed to mark notifications read", "error", err, "user_id", uid)
+		http.Error(w, "failed to mark notifications read", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
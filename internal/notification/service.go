@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+// Notification types stored in notifications.type.
+const (
+	TypePollClosed = "poll_closed"
+)
+
+type Service interface {
+	ListNotifications(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Notification], error)
+	MarkAllRead(ctx context.Context, userID int32) error
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// ListNotifications returns the notifications of the user, newest first.
+func (s *svc) ListNotifications(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[repo.Notification], error) {
+	notifications, err := s.repo.ListNotificationsByUserID(ctx, repo.ListNotificationsByUserIDParams{
+		UserID:     userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Notification]{}, err
+	}
+
+	notifications, c := pagination.Paginate(notifications, p, func(n repo.Notification) pagination.Key {
+		return pagination.Key{Time: n.CreatedAt.Time, ID: n.ID}
+	})
+	return pagination.NewPage(notifications, c), nil
+}
+
+func (s *svc) MarkAllRead(ctx context.Context, userID int32) error {
+	return s.repo.MarkNotificationsRead(ctx, userID)
+}
@@ -0,0 +1,55 @@
+package poll
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+)
+
+const closeBatchSize = 100
+
+// Closer periodically closes polls that have expired and notifies everyone
+// who voted in them. Closing and notifying happen in one statement, so a
+// voter is notified exactly once per poll.
+type Closer struct {
+	repo     repo.Querier
+	interval time.Duration
+}
+
+func NewCloser(repo repo.Querier, interval time.Duration) *Closer {
+	return &Closer{repo: repo, interval: interval}
+}
+
+func (c *Closer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.closeExpired(ctx)
+		}
+	}
+}
+
+func (c *Closer) closeExpired(ctx context.Context) {
+	for {
+		ids, err := c.repo.CloseExpiredPolls(ctx, closeBatchSize)
+		if err != nil {
+			slog.Error("failed to close expired polls", "error", err)
+			return
+		}
+
+		if len(ids) > 0 {
+			slog.Info("closed expired polls", "count", len(ids))
+		}
+
+		if len(ids) < closeBatchSize {
+			return
+		}
+	}
+}
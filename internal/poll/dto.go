@@ -0,0 +1,36 @@
+package poll
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreatePollRequest struct {
+	Options   []string  `json:"options"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Multiple  bool      `json:"multiple"`
+}
+
+type VoteRequest struct {
+	OptionIDs []int32 `json:"option_ids"`
+}
+
+// PollResponse is a poll as seen by one viewer. Until the viewer has voted
+// or the poll is closed, VotersCount and the per-option counts are null.
+type PollResponse struct {
+	ID          int32              `json:"id"`
+	Multiple    bool               `json:"multiple"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	Closed      bool               `json:"closed"`
+	VotersCount *int64             `json:"voters_count"`
+	Voted       bool               `json:"voted"`
+	OwnVotes    []int32            `json:"own_votes"`
+	Options     []OptionResponse   `json:"options"`
+}
+
+type OptionResponse struct {
+	ID         int32  `json:"id"`
+	Text       string `json:"text"`
+	VotesCount *int64 `json:"votes_count"`
+}
@@ -0,0 +1,55 @@
+package poll
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Vote(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	var req VoteRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read vote request", "error", err)
+		http.Error(w, "failed to read vote request", http.StatusBadRequest)
+		return
+	}
+
+	poll, err := h.service.Vote(r.Context(), int32(postID), uid, req)
+	if err != nil {
+		switch err {
+		case ErrPollNotFound:
+			http.Error(w, "poll not found", http.StatusNotFound)
+		case ErrInvalidChoice:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrPollClosed, ErrAlreadyVoted:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			slog.Error("failed to vote", "error", err, "post_id", postID, "user_id", uid)
+			http.Error(w, "failed to vote", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, poll)
+}
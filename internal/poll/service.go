@@ -0,0 +1,234 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	MinOptions      = 2
+	MaxOptions      = 6
+	MaxOptionLength = 100
+	MinDuration     = 5 * time.Minute
+	MaxDuration     = 30 * 24 * time.Hour
+)
+
+var (
+	ErrPollNotFound     = errors.New("poll not found")
+	ErrPollClosed       = errors.New("poll is closed")
+	ErrAlreadyVoted     = errors.New("already voted in this poll")
+	ErrInvalidChoice    = errors.New("invalid poll choice")
+	ErrInvalidOptions   = errors.New("a poll needs 2 to 6 distinct options of at most 100 characters")
+	ErrInvalidExpiresAt = errors.New("poll must stay open between 5 minutes and 30 days")
+)
+
+type Service interface {
+	Validate(req CreatePollRequest, opensAt time.Time) error
+	Create(ctx context.Context, postID int32, req CreatePollRequest) error
+	Vote(ctx context.Context, postID, userID int32, req VoteRequest) (PollResponse, error)
+	ListByPostIDs(ctx context.Context, postIDs []int32, viewerID int32) (map[int32]*PollResponse, error)
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// Validate checks a poll before its post is created. opensAt is when the post
+// becomes visible, so scheduled posts cannot carry a poll that has already
+// expired by the time it is published.
+func (s *svc) Validate(req CreatePollRequest, opensAt time.Time) error {
+	if len(req.Options) < MinOptions || len(req.Options) > MaxOptions {
+		return ErrInvalidOptions
+	}
+
+	seen := make(map[string]bool, len(req.Options))
+	for _, o := range req.Options {
+		o = strings.TrimSpace(o)
+		if o == "" || utf8.RuneCountInString(o) > MaxOptionLength || seen[o] {
+			return ErrInvalidOptions
+		}
+		seen[o] = true
+	}
+
+	open := req.ExpiresAt.Sub(opensAt)
+	if open < MinDuration || open > MaxDuration {
+		return ErrInvalidExpiresAt
+	}
+	return nil
+}
+
+func (s *svc) Create(ctx context.Context, postID int32, req CreatePollRequest) error {
+	options := make([]string, len(req.Options))
+	for i, o := range req.Options {
+		options[i] = strings.TrimSpace(o)
+	}
+
+	_, err := s.repo.CreatePoll(ctx, repo.CreatePollParams{
+		PostID:    postID,
+		Multiple:  req.Multiple,
+		ExpiresAt: pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+		Options:   options,
+	})
+	return err
+}
+
+// Vote records the choices of userID. A user votes once per poll, picking a
+// single option unless the poll allows multiple choices.
+func (s *svc) Vote(ctx context.Context, postID, userID int32, req VoteRequest) (PollResponse, error) {
+	p, err := s.repo.FindVisiblePollByPostID(ctx, repo.FindVisiblePollByPostIDParams{
+		PostID:   postID,
+		ViewerID: userID,
+	})
+	if err != nil {
+		return PollResponse{}, ErrPollNotFound
+	}
+
+	if isClosed(p) {
+		return PollResponse{}, ErrPollClosed
+	}
+
+	if len(req.OptionIDs) == 0 || (!p.Multiple && len(req.OptionIDs) > 1) || hasDuplicates(req.OptionIDs) {
+		return PollResponse{}, ErrInvalidChoice
+	}
+
+	options, err := s.repo.ListPollOptionsByPollIDs(ctx, []int32{p.ID})
+	if err != nil {
+		return PollResponse{}, err
+	}
+	for _, id := range req.OptionIDs {
+		if !slices.ContainsFunc(options, func(o repo.ListPollOptionsByPollIDsRow) bool { return o.ID == id }) {
+			return PollResponse{}, ErrInvalidChoice
+		}
+	}
+
+	n, err := s.repo.CreatePollVotes(ctx, repo.CreatePollVotesParams{
+		UserID:    userID,
+		PollID:    p.ID,
+		OptionIds: req.OptionIDs,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return PollResponse{}, ErrAlreadyVoted
+		}
+		return PollResponse{}, err
+	}
+	if n == 0 {
+		// Either an earlier vote exists or the poll expired in between.
+		p, err := s.repo.FindVisiblePollByPostID(ctx, repo.FindVisiblePollByPostIDParams{
+			PostID:   postID,
+			ViewerID: userID,
+		})
+		if err == nil && isClosed(p) {
+			return PollResponse{}, ErrPollClosed
+		}
+		return PollResponse{}, ErrAlreadyVoted
+	}
+
+	polls, err := s.ListByPostIDs(ctx, []int32{postID}, userID)
+	if err != nil {
+		return PollResponse{}, err
+	}
+	return *polls[postID], nil
+}
+
+// ListByPostIDs loads the polls of the given posts keyed by post id. Results
+// are only included for polls viewerID has voted in or that are closed.
+func (s *svc) ListByPostIDs(ctx context.Context, postIDs []int32, viewerID int32) (map[int32]*PollResponse, error) {
+	polls, err := s.repo.ListPollsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(polls) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int32, len(polls))
+	for i, p := range polls {
+		ids[i] = p.ID
+	}
+
+	options, err := s.repo.ListPollOptionsByPollIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.CountPollVotersByPollIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	voters := make(map[int32]int64, len(counts))
+	for _, c := range counts {
+		voters[c.PollID] = c.Count
+	}
+
+	own := make(map[int32][]int32)
+	if viewerID != 0 {
+		votes, err := s.repo.ListPollVotesByUserID(ctx, repo.ListPollVotesByUserIDParams{
+			PollIds: ids,
+			UserID:  viewerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range votes {
+			own[v.PollID] = append(own[v.PollID], v.OptionID)
+		}
+	}
+
+	byID := make(map[int32]*PollResponse, len(polls))
+	res := make(map[int32]*PollResponse, len(polls))
+	for _, p := range polls {
+		r := &PollResponse{
+			ID:        p.ID,
+			Multiple:  p.Multiple,
+			ExpiresAt: p.ExpiresAt,
+			Closed:    isClosed(p),
+			Voted:     len(own[p.ID]) > 0,
+			OwnVotes:  own[p.ID],
+			Options:   []OptionResponse{},
+		}
+		if r.OwnVotes == nil {
+			r.OwnVotes = []int32{}
+		}
+		if r.Voted || r.Closed {
+			count := voters[p.ID]
+			r.VotersCount = &count
+		}
+		byID[p.ID] = r
+		res[p.PostID] = r
+	}
+
+	for _, o := range options {
+		r := byID[o.PollID]
+		option := OptionResponse{ID: o.ID, Text: o.Text}
+		if r.VotersCount != nil {
+			count := o.VotesCount
+			option.VotesCount = &count
+		}
+		r.Options = append(r.Options, option)
+	}
+	return res, nil
+}
+
+func isClosed(p repo.Poll) bool {
+	return p.ClosedAt.Valid || !p.ExpiresAt.Time.After(time.Now())
+}
+
+func hasDuplicates(ids []int32) bool {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return len(slices.Compact(sorted)) != len(ids)
+}
@@ -1,11 +1,92 @@
 package post
 
+import (
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/media"
+	"github.com/etherealsense/social-network/internal/poll"
+	"github.com/etherealsense/social-network/internal/preview"
+	"github.com/etherealsense/social-network/pkg/diff"
+	"github.com/etherealsense/social-network/pkg/mention"
+)
+
 type CreatePostRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title          string                  `json:"title"`
+	Content        string                  `json:"content"`
+	Status         string                  `json:"status"`
+	PublishAt      *time.Time              `json:"publish_at"`
+	AttachmentIDs  []int32                 `json:"attachment_ids"`
+	QuotePostID    *int32                  `json:"quote_post_id"`
+	Visibility     string                  `json:"visibility"`
+	Poll           *poll.CreatePollRequest `json:"poll"`
+	ContentWarning string                  `json:"content_warning"`
+	Sensitive      bool                    `json:"sensitive"`
+	Language       string                  `json:"language"`
 }
 
 type UpdatePostRequest struct {
-	Title   *string `json:"title"`
-	Content *string `json:"content"`
+	Title          *string    `json:"title"`
+	Content        *string    `json:"content"`
+	Status         *string    `json:"status"`
+	PublishAt      *time.Time `json:"publish_at"`
+	Visibility     *string    `json:"visibility"`
+	ContentWarning *string    `json:"content_warning"`
+	Sensitive      *bool      `json:"sensitive"`
+}
+
+// FlagPostRequest lets a moderator set the content warning or sensitive flag
+// of any post. Fields left out are kept.
+type FlagPostRequest struct {
+	ContentWarning *string `json:"content_warning"`
+	Sensitive      *bool   `json:"sensitive"`
+}
+
+// CommentSettingsRequest changes who may comment on a post. Fields left out
+// are kept.
+type CommentSettingsRequest struct {
+	Locked *bool   `json:"locked"`
+	Policy *string `json:"policy"`
+}
+
+type ReorderPinsRequest struct {
+	PostIDs []int32 `json:"post_ids"`
+}
+
+type RevisionDiffResponse struct {
+	From    int32     `json:"from"`
+	To      int32     `json:"to"`
+	Title   []diff.Op `json:"title"`
+	Content []diff.Op `json:"content"`
+}
+
+type PostResponse struct {
+	repo.Post
+	ContentHTML  string                     `json:"content_html,omitempty"`
+	Attachments  []media.AttachmentResponse `json:"attachments"`
+	Mentions     []mention.Entity           `json:"mentions"`
+	RepostsCount int64                      `json:"reposts_count"`
+	// Reactions counts the reactions to the post by type. ViewerReaction is
+	// null when the viewer has not reacted.
+	Reactions      map[string]int64     `json:"reactions"`
+	ViewerReaction *string              `json:"viewer_reaction"`
+	QuotedPost     *QuotedPost          `json:"quoted_post"`
+	Poll           *poll.PollResponse   `json:"poll"`
+	LinkPreview    *preview.LinkPreview `json:"link_preview"`
+	Pinned         bool                 `json:"pinned"`
+	Bookmarked     bool                 `json:"bookmarked"`
+	// ViewsCount is only set for the author of the post.
+	ViewsCount *int64 `json:"views_count"`
+	// Collapsed tells clients to hide the post behind its content warning,
+	// following the viewer's sensitive_content setting.
+	Collapsed bool `json:"collapsed"`
+}
+
+// QuotedPost is the original of a quote post. When the original is gone or
+// no longer published, only its id is kept and Tombstone is set.
+type QuotedPost struct {
+	ID          int32      `json:"id"`
+	Tombstone   bool       `json:"tombstone"`
+	Post        *repo.Post `json:"post,omitempty"`
+	ContentHTML string     `json:"content_html,omitempty"`
 }
@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/internal/media"
+	"github.com/etherealsense/social-network/internal/poll"
 	"github.com/etherealsense/social-network/pkg/json"
 	"github.com/etherealsense/social-network/pkg/pagination"
 	"github.com/go-chi/chi/v5"
@@ -31,8 +33,14 @@ func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 
 	post, err := h.service.CreatePost(r.Context(), uid, req)
 	if err != nil {
-		slog.Error("failed to create post", "error", err, "user_id", uid)
-		http.Error(w, "failed to create post", http.StatusInternalServerError)
+		switch err {
+		case ErrInvalidStatus, ErrInvalidPublishAt, ErrInvalidVisibility, ErrQuotedPostNotFound, ErrPostNotQuotable, ErrContentWarningTooLong, ErrInvalidLanguage,
+			media.ErrTooManyAttachments, media.ErrInvalidAttachments, poll.ErrInvalidOptions, poll.ErrInvalidExpiresAt:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to create post", "error", err, "user_id", uid)
+			http.Error(w, "failed to create post", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -47,7 +55,7 @@ func (h *Handler) GetPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	post, err := h.service.FindPostByID(r.Context(), int32(id))
+	post, err := h.service.FindPostByID(r.Context(), int32(id), auth.UserIDFromContext(r.Context()))
 	if err != nil {
 		switch err {
 		case ErrPostNotFound:
@@ -85,6 +93,10 @@ func (h *Handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "post not found", http.StatusNotFound)
 		case ErrPostForbidden:
 			http.Error(w, "forbidden", http.StatusForbidden)
+		case ErrInvalidStatus, ErrInvalidPublishAt, ErrInvalidVisibility, ErrContentWarningTooLong:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrAlreadyPublished:
+			http.Error(w, err.Error(), http.StatusConflict)
 		default:
 			slog.Error("failed to update post", "error", err, "post_id", id, "user_id", uid)
 			http.Error(w, "failed to update post", http.StatusInternalServerError)
@@ -122,6 +134,127 @@ func (h *Handler) DeletePost(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) RestorePost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.RestorePost(r.Context(), int32(id), uid)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrPostForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case ErrPostNotDeleted:
+			http.Error(w, "post is not deleted", http.StatusConflict)
+		default:
+			slog.Error("failed to restore post", "error", err, "post_id", id, "user_id", uid)
+			http.Error(w, "failed to restore post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, post)
+}
+
+func (h *Handler) GetPostIncludingDeleted(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.FindPostByIDIncludingDeleted(r.Context(), int32(id))
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		default:
+			http.Error(w, "failed to find post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, post)
+}
+
+func (h *Handler) FlagPost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	var req FlagPostRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read flag post request", "error", err)
+		http.Error(w, "failed to read flag post request", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.FlagPost(r.Context(), int32(id), req)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrContentWarningTooLong:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to flag post", "error", err, "post_id", id, "user_id", uid)
+			http.Error(w, "failed to flag post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, post)
+}
+
+func (h *Handler) UpdateCommentSettings(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	var req CommentSettingsRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read comment settings request", "error", err)
+		http.Error(w, "failed to read comment settings request", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.UpdateCommentSettings(r.Context(), int32(id), uid, req)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrPostForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case ErrInvalidCommentPolicy:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to update comment settings", "error", err, "post_id", id, "user_id", uid)
+			http.Error(w, "failed to update comment settings", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, post)
+}
+
 func (h *Handler) ListPostsByUserID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "user_id")
 	id, err := strconv.Atoi(idStr)
@@ -131,9 +264,13 @@ func (h *Handler) ListPostsByUserID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := pagination.Parse(r)
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 
-	posts, err := h.service.ListPostsByUserID(r.Context(), int32(id), p.Limit, p.Offset)
+	posts, err := h.service.ListPostsByUserID(r.Context(), int32(id), auth.UserIDFromContext(r.Context()), p)
 	if err != nil {
 		slog.Error("failed to list posts", "error", err, "user_id", id)
 		http.Error(w, "failed to list posts", http.StatusInternalServerError)
@@ -142,3 +279,207 @@ func (h *Handler) ListPostsByUserID(w http.ResponseWriter, r *http.Request) {
 
 	json.Write(w, http.StatusOK, posts)
 }
+
+func (h *Handler) ListDrafts(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	posts, err := h.service.ListDrafts(r.Context(), uid, p)
+	if err != nil {
+		slog.Error("failed to list drafts", "error", err, "user_id", uid)
+		http.Error(w, "failed to list drafts", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, posts)
+}
+
+func (h *Handler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.service.ListRevisions(r.Context(), int32(id), auth.UserIDFromContext(r.Context()), p)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to list revisions", "error", err, "post_id", id)
+			http.Error(w, "failed to list revisions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, revisions)
+}
+
+func (h *Handler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "invalid from revision", http.StatusBadRequest)
+		return
+	}
+
+	var to int
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err = strconv.Atoi(toStr)
+		if err != nil {
+			http.Error(w, "invalid to revision", http.StatusBadRequest)
+			return
+		}
+	}
+
+	res, err := h.service.DiffRevisions(r.Context(), int32(id), auth.UserIDFromContext(r.Context()), int32(from), int32(to))
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrRevisionNotFound:
+			http.Error(w, "revision not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to diff revisions", "error", err, "post_id", id)
+			http.Error(w, "failed to diff revisions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, res)
+}
+
+func (h *Handler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	revisionIDStr := chi.URLParam(r, "revision_id")
+	revisionID, err := strconv.Atoi(revisionIDStr)
+	if err != nil {
+		http.Error(w, "invalid revision id", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.RestoreRevision(r.Context(), int32(id), int32(revisionID), uid)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrRevisionNotFound:
+			http.Error(w, "revision not found", http.StatusNotFound)
+		case ErrPostForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		default:
+			slog.Error("failed to restore revision", "error", err, "post_id", id, "revision_id", revisionID)
+			http.Error(w, "failed to restore revision", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, post)
+}
+
+func (h *Handler) PinPost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.PinPost(r.Context(), int32(id), uid)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrPostForbidden:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case ErrPostNotPinnable, ErrTooManyPins:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrAlreadyPinned:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			slog.Error("failed to pin post", "error", err, "post_id", id, "user_id", uid)
+			http.Error(w, "failed to pin post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, post)
+}
+
+func (h *Handler) UnpinPost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.UnpinPost(r.Context(), int32(id), uid)
+	if err != nil {
+		switch err {
+		case ErrPostNotPinned:
+			http.Error(w, "post is not pinned", http.StatusNotFound)
+		default:
+			slog.Error("failed to unpin post", "error", err, "post_id", id, "user_id", uid)
+			http.Error(w, "failed to unpin post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ReorderPins(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	var req ReorderPinsRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read reorder pins request", "error", err)
+		http.Error(w, "failed to read reorder pins request", http.StatusBadRequest)
+		return
+	}
+
+	posts, err := h.service.ReorderPins(r.Context(), uid, req)
+	if err != nil {
+		switch err {
+		case ErrInvalidPinOrder:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to reorder pins", "error", err, "user_id", uid)
+			http.Error(w, "failed to reorder pins", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, posts)
+}
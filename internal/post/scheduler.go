@@ -0,0 +1,56 @@
+package post
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+)
+
+const publishBatchSize = 100
+
+// Scheduler periodically publishes scheduled posts whose publish_at has
+// passed and queues them for timeline fan-out in the same statement. Due rows
+// are claimed with FOR UPDATE SKIP LOCKED, so several API instances can run it
+// side by side without publishing a post twice.
+type Scheduler struct {
+	repo     repo.Querier
+	interval time.Duration
+}
+
+func NewScheduler(repo repo.Querier, interval time.Duration) *Scheduler {
+	return &Scheduler{repo: repo, interval: interval}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.publishDue(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) publishDue(ctx context.Context) {
+	for {
+		posts, err := s.repo.PublishDuePosts(ctx, publishBatchSize)
+		if err != nil {
+			slog.Error("failed to publish scheduled posts", "error", err)
+			return
+		}
+
+		if len(posts) > 0 {
+			slog.Info("published scheduled posts", "count", len(posts))
+		}
+
+		if len(posts) < publishBatchSize {
+			return
+		}
+	}
+}
@@ -3,57 +3,247 @@ package post
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/impression"
+	"github.com/etherealsense/social-network/internal/media"
+	"github.com/etherealsense/social-network/internal/poll"
+	"github.com/etherealsense/social-network/internal/preview"
+	"github.com/etherealsense/social-network/internal/reaction"
+	"github.com/etherealsense/social-network/internal/user"
+	"github.com/etherealsense/social-network/pkg/database"
+	"github.com/etherealsense/social-network/pkg/diff"
+	"github.com/etherealsense/social-network/pkg/hashtag"
+	"github.com/etherealsense/social-network/pkg/markdown"
+	"github.com/etherealsense/social-network/pkg/mention"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/etherealsense/social-network/pkg/textsearch"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusPublished = "published"
+)
+
+const (
+	MaxPinnedPosts          = 3
+	MaxContentWarningLength = 200
+)
+
+// Visibility levels decide who can read a post, see can_view_post in the
+// migrations.
+const (
+	VisibilityPublic    = "public"
+	VisibilityFollowers = "followers"
+	VisibilityMentioned = "mentioned"
+	VisibilityPrivate   = "private"
+)
+
+// Comment policies decide who besides the author may comment on a post.
+// CommentsMentioned allows only the users mentioned in the post.
+const (
+	CommentsEveryone  = "everyone"
+	CommentsFollowers = "followers"
+	CommentsMentioned = "mentioned"
+)
+
 var (
-	ErrPostAlreadyExists = errors.New("post already exists")
-	ErrPostNotFound      = errors.New("post not found")
-	ErrPostForbidden     = errors.New("forbidden")
+	ErrPostAlreadyExists     = errors.New("post already exists")
+	ErrPostNotFound          = errors.New("post not found")
+	ErrPostForbidden         = errors.New("forbidden")
+	ErrInvalidStatus         = errors.New("invalid post status")
+	ErrInvalidPublishAt      = errors.New("publish_at must be in the future")
+	ErrAlreadyPublished      = errors.New("post is already published")
+	ErrRevisionNotFound      = errors.New("revision not found")
+	ErrPostNotDeleted        = errors.New("post is not deleted")
+	ErrQuotedPostNotFound    = errors.New("quoted post not found")
+	ErrPostNotQuotable       = errors.New("only public posts can be quoted")
+	ErrInvalidVisibility     = errors.New("invalid post visibility")
+	ErrPostNotPinnable       = errors.New("only published public posts can be pinned")
+	ErrAlreadyPinned         = errors.New("post is already pinned")
+	ErrPostNotPinned         = errors.New("post is not pinned")
+	ErrTooManyPins           = errors.New("too many pinned posts")
+	ErrInvalidPinOrder       = errors.New("order must list every pinned post exactly once")
+	ErrContentWarningTooLong = errors.New("content_warning must be at most 200 characters")
+	ErrInvalidLanguage       = errors.New("invalid language")
+	ErrInvalidCommentPolicy  = errors.New("comment_policy must be one of everyone, followers or mentioned")
 )
 
 type Service interface {
-	CreatePost(ctx context.Context, userID int32, req CreatePostRequest) (repo.Post, error)
-	FindPostByID(ctx context.Context, id int32) (repo.Post, error)
-	UpdatePost(ctx context.Context, id int32, userID int32, req UpdatePostRequest) (repo.Post, error)
+	CreatePost(ctx context.Context, userID int32, req CreatePostRequest) (PostResponse, error)
+	FindPostByID(ctx context.Context, id, viewerID int32) (PostResponse, error)
+	UpdatePost(ctx context.Context, id int32, userID int32, req UpdatePostRequest) (PostResponse, error)
 	DeletePost(ctx context.Context, id int32, userID int32) error
-	ListPostsByUserID(ctx context.Context, userID int32, limit, offset int32) ([]repo.Post, error)
+	RestorePost(ctx context.Context, id int32, userID int32) (PostResponse, error)
+	FindPostByIDIncludingDeleted(ctx context.Context, id int32) (PostResponse, error)
+	FlagPost(ctx context.Context, id int32, req FlagPostRequest) (PostResponse, error)
+	UpdateCommentSettings(ctx context.Context, id int32, userID int32, req CommentSettingsRequest) (PostResponse, error)
+	ListPostsByUserID(ctx context.Context, userID, viewerID int32, p pagination.Params) (pagination.Page[PostResponse], error)
+	ListDrafts(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[PostResponse], error)
+	ListRevisions(ctx context.Context, postID, viewerID int32, p pagination.Params) (pagination.Page[repo.PostRevision], error)
+	DiffRevisions(ctx context.Context, postID, viewerID, fromID, toID int32) (RevisionDiffResponse, error)
+	RestoreRevision(ctx context.Context, postID, revisionID, userID int32) (PostResponse, error)
+	PinPost(ctx context.Context, id, userID int32) (PostResponse, error)
+	UnpinPost(ctx context.Context, id, userID int32) error
+	ReorderPins(ctx context.Context, userID int32, req ReorderPinsRequest) ([]PostResponse, error)
+	ToResponses(ctx context.Context, posts []repo.Post, viewerID int32) ([]PostResponse, error)
 }
 
 type svc struct {
-	repo repo.Querier
+	repo        repo.Querier
+	tx          database.Transactor
+	media       media.Service
+	polls       poll.Service
+	previews    preview.Service
+	reactions   reaction.Service
+	impressions impression.Recorder
 }
 
-func NewService(repo repo.Querier) Service {
-	return &svc{repo: repo}
+func NewService(repo repo.Querier, tx database.Transactor, media media.Service, polls poll.Service, previews preview.Service, reactions reaction.Service, impressions impression.Recorder) Service {
+	return &svc{repo: repo, tx: tx, media: media, polls: polls, previews: previews, reactions: reactions, impressions: impressions}
 }
 
-func (s *svc) CreatePost(ctx context.Context, userID int32, req CreatePostRequest) (repo.Post, error) {
-	return s.repo.CreatePost(ctx, repo.CreatePostParams{
-		UserID:  userID,
-		Title:   req.Title,
-		Content: req.Content,
+func (s *svc) CreatePost(ctx context.Context, userID int32, req CreatePostRequest) (PostResponse, error) {
+	status, publishAt, err := resolveStatus(req.Status, req.PublishAt)
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+	if !validVisibility(visibility) {
+		return PostResponse{}, ErrInvalidVisibility
+	}
+
+	contentWarning, err := normalizeContentWarning(req.ContentWarning)
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	language := req.Language
+	if language == "" {
+		language = textsearch.DefaultLanguage
+	}
+	if !textsearch.ValidLanguage(language) {
+		return PostResponse{}, ErrInvalidLanguage
+	}
+
+	if len(req.AttachmentIDs) > 0 {
+		if err := s.media.ValidateAttachable(ctx, userID, req.AttachmentIDs); err != nil {
+			return PostResponse{}, err
+		}
+	}
+
+	if req.Poll != nil {
+		opensAt := time.Now()
+		if publishAt.Valid {
+			opensAt = publishAt.Time
+		}
+		if err := s.polls.Validate(*req.Poll, opensAt); err != nil {
+			return PostResponse{}, err
+		}
+	}
+
+	var quotePostID pgtype.Int4
+	if req.QuotePostID != nil {
+		quoted, err := s.findVisiblePost(ctx, *req.QuotePostID, userID)
+		if err != nil {
+			return PostResponse{}, ErrQuotedPostNotFound
+		}
+		if quoted.Visibility != VisibilityPublic {
+			return PostResponse{}, ErrPostNotQuotable
+		}
+		quotePostID = pgtype.Int4{Int32: *req.QuotePostID, Valid: true}
+	}
+
+	// The post is created along with its attachments, poll, entities and
+	// fan-out in one transaction, so a failed step leaves no half-built post.
+	var post repo.Post
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		post, err = s.repo.CreatePost(ctx, repo.CreatePostParams{
+			UserID:         userID,
+			Title:          req.Title,
+			Content:        req.Content,
+			Status:         status,
+			PublishAt:      publishAt,
+			QuotePostID:    quotePostID,
+			Visibility:     visibility,
+			ContentWarning: contentWarning,
+			Sensitive:      req.Sensitive,
+			Language:       language,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(req.AttachmentIDs) > 0 {
+			if err := s.media.Attach(ctx, userID, post.ID, req.AttachmentIDs); err != nil {
+				return err
+			}
+		}
+
+		if req.Poll != nil {
+			if err := s.polls.Create(ctx, post.ID, *req.Poll); err != nil {
+				return err
+			}
+		}
+
+		if err := s.setEntities(ctx, &post); err != nil {
+			return err
+		}
+
+		if post.Status == StatusPublished {
+			return s.repo.QueueTimelineFanout(ctx, []int32{post.ID})
+		}
+		return nil
 	})
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, userID)
 }
 
-func (s *svc) FindPostByID(ctx context.Context, id int32) (repo.Post, error) {
-	post, err := s.repo.FindPostByID(ctx, id)
+// FindPostByID returns a published post if viewerID may read it and records
+// an impression. A viewerID of 0 is an anonymous viewer.
+func (s *svc) FindPostByID(ctx context.Context, id, viewerID int32) (PostResponse, error) {
+	post, err := s.findVisiblePost(ctx, id, viewerID)
 	if err != nil {
+		return PostResponse{}, err
+	}
+
+	s.impressions.Record(viewerID, post.ID)
+	return s.toResponse(ctx, post, viewerID)
+}
+
+func (s *svc) findVisiblePost(ctx context.Context, id, viewerID int32) (repo.Post, error) {
+	post, err := s.repo.FindVisiblePostByID(ctx, repo.FindVisiblePostByIDParams{
+		ID:       id,
+		ViewerID: viewerID,
+	})
+	if err != nil || post.Status != StatusPublished {
 		return repo.Post{}, ErrPostNotFound
 	}
 	return post, nil
 }
 
-func (s *svc) UpdatePost(ctx context.Context, id int32, userID int32, req UpdatePostRequest) (repo.Post, error) {
+func (s *svc) UpdatePost(ctx context.Context, id int32, userID int32, req UpdatePostRequest) (PostResponse, error) {
 	post, err := s.repo.FindPostByID(ctx, id)
 	if err != nil {
-		return repo.Post{}, ErrPostNotFound
+		return PostResponse{}, ErrPostNotFound
 	}
 
 	if post.UserID != userID {
-		return repo.Post{}, ErrPostForbidden
+		return PostResponse{}, ErrPostForbidden
 	}
 
 	params := repo.UpdatePostParams{
@@ -68,7 +258,65 @@ func (s *svc) UpdatePost(ctx context.Context, id int32, userID int32, req Update
 		params.Content = pgtype.Text{String: *req.Content, Valid: true}
 	}
 
-	return s.repo.UpdatePost(ctx, params)
+	if req.Visibility != nil {
+		if !validVisibility(*req.Visibility) {
+			return PostResponse{}, ErrInvalidVisibility
+		}
+		params.Visibility = pgtype.Text{String: *req.Visibility, Valid: true}
+	}
+
+	if req.ContentWarning != nil {
+		contentWarning, err := normalizeContentWarning(*req.ContentWarning)
+		if err != nil {
+			return PostResponse{}, err
+		}
+		params.ContentWarning = pgtype.Text{String: contentWarning, Valid: true}
+	}
+
+	if req.Sensitive != nil {
+		params.Sensitive = pgtype.Bool{Bool: *req.Sensitive, Valid: true}
+	}
+
+	if req.Status != nil || req.PublishAt != nil {
+		if post.Status == StatusPublished {
+			return PostResponse{}, ErrAlreadyPublished
+		}
+
+		var status string
+		if req.Status != nil {
+			status = *req.Status
+		}
+
+		status, publishAt, err := resolveStatus(status, req.PublishAt)
+		if err != nil {
+			return PostResponse{}, err
+		}
+		params.Status = pgtype.Text{String: status, Valid: true}
+		params.PublishAt = publishAt
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		post, err = s.repo.UpdatePost(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if req.Title != nil || req.Content != nil {
+			if err := s.setEntities(ctx, &post); err != nil {
+				return err
+			}
+		}
+
+		if params.Status.Valid && post.Status == StatusPublished {
+			return s.repo.QueueTimelineFanout(ctx, []int32{post.ID})
+		}
+		return nil
+	})
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, userID)
 }
 
 func (s *svc) DeletePost(ctx context.Context, id int32, userID int32) error {
@@ -84,15 +332,670 @@ func (s *svc) DeletePost(ctx context.Context, id int32, userID int32) error {
 	return s.repo.DeletePost(ctx, id)
 }
 
-func (s *svc) ListPostsByUserID(ctx context.Context, userID int32, limit, offset int32) ([]repo.Post, error) {
-	posts, err := s.repo.ListPostsByUserID(ctx, repo.ListPostsByUserIDParams{
+func (s *svc) RestorePost(ctx context.Context, id int32, userID int32) (PostResponse, error) {
+	post, err := s.repo.FindPostByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return PostResponse{}, ErrPostNotFound
+	}
+
+	if post.UserID != userID {
+		return PostResponse{}, ErrPostForbidden
+	}
+
+	if !post.DeletedAt.Valid {
+		return PostResponse{}, ErrPostNotDeleted
+	}
+
+	post, err = s.repo.RestorePost(ctx, id)
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, userID)
+}
+
+// FindPostByIDIncludingDeleted returns the post even when it has been soft
+// deleted, so moderators can inspect tombstones.
+func (s *svc) FindPostByIDIncludingDeleted(ctx context.Context, id int32) (PostResponse, error) {
+	post, err := s.repo.FindPostByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return PostResponse{}, ErrPostNotFound
+	}
+	return s.toResponse(ctx, post, 0)
+}
+
+// flagged reports whether the author or a moderator put a content warning or
+// sensitive flag on p.
+func flagged(p repo.Post) bool {
+	return p.ContentWarning != "" || p.Sensitive || p.ModeratorContentWarning != "" || p.ModeratorSensitive
+}
+
+// FlagPost sets the content warning or sensitive flag of a post on behalf of
+// a moderator. Unlike UpdatePost it does not check the author, and the flags
+// are kept apart from the author's so an edit can't clear them.
+func (s *svc) FlagPost(ctx context.Context, id int32, req FlagPostRequest) (PostResponse, error) {
+	if _, err := s.repo.FindPostByID(ctx, id); err != nil {
+		return PostResponse{}, ErrPostNotFound
+	}
+
+	params := repo.SetPostFlagsParams{
+		ID: id,
+	}
+
+	if req.ContentWarning != nil {
+		contentWarning, err := normalizeContentWarning(*req.ContentWarning)
+		if err != nil {
+			return PostResponse{}, err
+		}
+		params.ModeratorContentWarning = pgtype.Text{String: contentWarning, Valid: true}
+	}
+
+	if req.Sensitive != nil {
+		params.ModeratorSensitive = pgtype.Bool{Bool: *req.Sensitive, Valid: true}
+	}
+
+	post, err := s.repo.SetPostFlags(ctx, params)
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, 0)
+}
+
+// UpdateCommentSettings locks or unlocks a post against new comments and sets
+// who may comment on it.
+func (s *svc) UpdateCommentSettings(ctx context.Context, id int32, userID int32, req CommentSettingsRequest) (PostResponse, error) {
+	post, err := s.repo.FindPostByID(ctx, id)
+	if err != nil {
+		return PostResponse{}, ErrPostNotFound
+	}
+
+	if post.UserID != userID {
+		return PostResponse{}, ErrPostForbidden
+	}
+
+	params := repo.SetPostCommentSettingsParams{
+		ID: id,
+	}
+
+	if req.Locked != nil {
+		params.CommentsLocked = pgtype.Bool{Bool: *req.Locked, Valid: true}
+	}
+
+	if req.Policy != nil {
+		switch *req.Policy {
+		case CommentsEveryone, CommentsFollowers, CommentsMentioned:
+		default:
+			return PostResponse{}, ErrInvalidCommentPolicy
+		}
+		params.CommentPolicy = pgtype.Text{String: *req.Policy, Valid: true}
+	}
+
+	post, err = s.repo.SetPostCommentSettings(ctx, params)
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, userID)
+}
+
+// ListPostsByUserID lists the posts of a user, pinned posts first in pin
+// order and then newest first.
+func (s *svc) ListPostsByUserID(ctx context.Context, userID, viewerID int32, p pagination.Params) (pagination.Page[PostResponse], error) {
+	rows, err := s.repo.ListPostsByUserID(ctx, repo.ListPostsByUserIDParams{
+		UserID:      userID,
+		ViewerID:    viewerID,
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorTime:  p.CursorTime(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[PostResponse]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.ListPostsByUserIDRow) pagination.Key {
+		return pagination.Key{Time: r.Post.CreatedAt.Time, Score: r.PinRank, ID: r.Post.ID}
+	})
+
+	posts := make([]repo.Post, len(rows))
+	for i, r := range rows {
+		posts[i] = r.Post
+	}
+
+	res, err := s.ToResponses(ctx, posts, viewerID)
+	if err != nil {
+		return pagination.Page[PostResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+func (s *svc) ListDrafts(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[PostResponse], error) {
+	posts, err := s.repo.ListDraftsByUserID(ctx, repo.ListDraftsByUserIDParams{
+		UserID:     userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[PostResponse]{}, err
+	}
+
+	posts, c := pagination.Paginate(posts, p, func(d repo.Post) pagination.Key {
+		return pagination.Key{Time: d.UpdatedAt.Time, ID: d.ID}
+	})
+
+	res, err := s.ToResponses(ctx, posts, userID)
+	if err != nil {
+		return pagination.Page[PostResponse]{}, err
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+func (s *svc) ListRevisions(ctx context.Context, postID, viewerID int32, p pagination.Params) (pagination.Page[repo.PostRevision], error) {
+	if _, err := s.findVisiblePost(ctx, postID, viewerID); err != nil {
+		return pagination.Page[repo.PostRevision]{}, err
+	}
+
+	revisions, err := s.repo.ListPostRevisions(ctx, repo.ListPostRevisionsParams{
+		PostID:     postID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.PostRevision]{}, err
+	}
+
+	revisions, c := pagination.Paginate(revisions, p, func(r repo.PostRevision) pagination.Key {
+		return pagination.Key{Time: r.CreatedAt.Time, ID: r.ID}
+	})
+	return pagination.NewPage(revisions, c), nil
+}
+
+// DiffRevisions compares two revisions of a post. A toID of 0 compares
+// against the current version of the post.
+func (s *svc) DiffRevisions(ctx context.Context, postID, viewerID, fromID, toID int32) (RevisionDiffResponse, error) {
+	post, err := s.findVisiblePost(ctx, postID, viewerID)
+	if err != nil {
+		return RevisionDiffResponse{}, err
+	}
+
+	from, err := s.repo.FindPostRevisionByID(ctx, repo.FindPostRevisionByIDParams{
+		ID:     fromID,
+		PostID: postID,
+	})
+	if err != nil {
+		return RevisionDiffResponse{}, ErrRevisionNotFound
+	}
+
+	toTitle, toContent := post.Title, post.Content
+	if toID != 0 {
+		to, err := s.repo.FindPostRevisionByID(ctx, repo.FindPostRevisionByIDParams{
+			ID:     toID,
+			PostID: postID,
+		})
+		if err != nil {
+			return RevisionDiffResponse{}, ErrRevisionNotFound
+		}
+		toTitle, toContent = to.Title, to.Content
+	}
+
+	return RevisionDiffResponse{
+		From:    fromID,
+		To:      toID,
+		Title:   diff.Words(from.Title, toTitle),
+		Content: diff.Words(from.Content, toContent),
+	}, nil
+}
+
+// RestoreRevision makes an older revision current again. The version being
+// replaced is itself kept as a new revision.
+func (s *svc) RestoreRevision(ctx context.Context, postID, revisionID, userID int32) (PostResponse, error) {
+	post, err := s.repo.FindPostByID(ctx, postID)
+	if err != nil {
+		return PostResponse{}, ErrPostNotFound
+	}
+
+	if post.UserID != userID {
+		return PostResponse{}, ErrPostForbidden
+	}
+
+	rev, err := s.repo.FindPostRevisionByID(ctx, repo.FindPostRevisionByIDParams{
+		ID:     revisionID,
+		PostID: postID,
+	})
+	if err != nil {
+		return PostResponse{}, ErrRevisionNotFound
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		post, err = s.repo.UpdatePost(ctx, repo.UpdatePostParams{
+			ID:      postID,
+			Title:   pgtype.Text{String: rev.Title, Valid: true},
+			Content: pgtype.Text{String: rev.Content, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return s.setEntities(ctx, &post)
+	})
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, userID)
+}
+
+// PinPost pins a post to the top of its author's profile, after the posts
+// already pinned. Pins are removed when the post is deleted or stops being
+// public.
+func (s *svc) PinPost(ctx context.Context, id, userID int32) (PostResponse, error) {
+	post, err := s.repo.FindPostByID(ctx, id)
+	if err != nil {
+		return PostResponse{}, ErrPostNotFound
+	}
+
+	if post.UserID != userID {
+		return PostResponse{}, ErrPostForbidden
+	}
+
+	if post.Status != StatusPublished || post.Visibility != VisibilityPublic {
+		return PostResponse{}, ErrPostNotPinnable
+	}
+
+	// Pins of a user are serialized, so concurrent pins cannot both pass the
+	// limit or take the same position.
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPinnedPosts(ctx, userID); err != nil {
+			return err
+		}
+
+		n, err := s.repo.PinPost(ctx, repo.PinPostParams{
+			UserID:  userID,
+			PostID:  id,
+			MaxPins: MaxPinnedPosts,
+		})
+		if err != nil {
+			if database.IsUniqueViolation(err) {
+				return ErrAlreadyPinned
+			}
+			return err
+		}
+
+		if n == 0 {
+			return ErrTooManyPins
+		}
+		return nil
+	})
+	if err != nil {
+		return PostResponse{}, err
+	}
+
+	return s.toResponse(ctx, post, userID)
+}
+
+func (s *svc) UnpinPost(ctx context.Context, id, userID int32) error {
+	n, err := s.repo.UnpinPost(ctx, repo.UnpinPostParams{
 		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
+		PostID: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrPostNotPinned
+	}
+	return nil
+}
+
+// ReorderPins sets the order of the pinned posts of userID to the order of
+// req.PostIDs.
+func (s *svc) ReorderPins(ctx context.Context, userID int32, req ReorderPinsRequest) ([]PostResponse, error) {
+	var pinned []repo.Post
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPinnedPosts(ctx, userID); err != nil {
+			return err
+		}
+
+		current, err := s.repo.ListPinnedPostsByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if len(req.PostIDs) != len(current) {
+			return ErrInvalidPinOrder
+		}
+		for _, p := range current {
+			if !slices.Contains(req.PostIDs, p.ID) {
+				return ErrInvalidPinOrder
+			}
+		}
+
+		n, err := s.repo.ReorderPinnedPosts(ctx, repo.ReorderPinnedPostsParams{
+			PostIds: req.PostIDs,
+			UserID:  userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if n != int64(len(req.PostIDs)) {
+			return ErrInvalidPinOrder
+		}
+
+		pinned, err = s.repo.ListPinnedPostsByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ToResponses(ctx, pinned, userID)
+}
+
+// setEntities replaces the hashtags and mentions of the post with the ones
+// found in its current text, renders its content to HTML and queues a link
+// preview for its first URL. Mentions of users who blocked the author or do
+// not accept mentions from them are dropped.
+func (s *svc) setEntities(ctx context.Context, post *repo.Post) error {
+	err := s.repo.SetPostHashtags(ctx, repo.SetPostHashtagsParams{
+		Names:  hashtag.Extract(post.Title + "\n" + post.Content),
+		PostID: post.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	users, err := s.repo.ResolveMentions(ctx, repo.ResolveMentionsParams{
+		Handles:  mention.Handles(post.Title + "\n" + post.Content),
+		AuthorID: post.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int32, len(users))
+	handles := make(map[string]int32, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+		handles[u.Handle] = u.ID
+	}
+
+	err = s.repo.SetPostMentions(ctx, repo.SetPostMentionsParams{
+		PostID:   post.ID,
+		UserIds:  ids,
+		AuthorID: post.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	post.ContentHtml = markdown.Render(post.Content, handles)
+	err = s.repo.SetPostContentHTML(ctx, repo.SetPostContentHTMLParams{
+		ID:          post.ID,
+		ContentHtml: post.ContentHtml,
 	})
+	if err != nil {
+		return err
+	}
+
+	return s.previews.SetPostLink(ctx, post.ID, post.Content)
+}
+
+// ToResponses attaches the media, mention ranges, repost and reaction counts,
+// polls, link previews and quoted originals of each post. Poll results, the
+// viewer's reaction and the bookmarked flag depend on viewerID. It is shared
+// with other packages that list posts, such as the feed.
+func (s *svc) ToResponses(ctx context.Context, posts []repo.Post, viewerID int32) ([]PostResponse, error) {
+	ids := make([]int32, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+
+	attachments, err := s.media.ListByPostIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListMentionsByPostIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := make(map[int32]map[string]int32)
+	for _, row := range rows {
+		if mentioned[row.PostID] == nil {
+			mentioned[row.PostID] = make(map[string]int32)
+		}
+		mentioned[row.PostID][row.Handle] = row.UserID
+	}
+
+	counts, err := s.repo.CountRepostsByPostIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	reposts := make(map[int32]int64, len(counts))
+	for _, c := range counts {
+		reposts[c.PostID] = c.Count
+	}
+
+	polls, err := s.polls.ListByPostIDs(ctx, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	previews, err := s.previews.ListByPostIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions, err := s.reactions.ListByPostIDs(ctx, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	pinnedIDs, err := s.repo.ListPinnedPostIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[int32]bool, len(pinnedIDs))
+	for _, id := range pinnedIDs {
+		pinned[id] = true
+	}
+
+	bookmarked := make(map[int32]bool)
+	if viewerID != 0 {
+		postIDs, err := s.repo.ListBookmarkedPostIDs(ctx, repo.ListBookmarkedPostIDsParams{
+			UserID:  viewerID,
+			PostIds: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range postIDs {
+			bookmarked[id] = true
+		}
+	}
+
+	views, err := s.viewCounts(ctx, posts, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	expand := false
+	if viewerID != 0 {
+		setting, err := s.repo.FindUserSensitiveContentByID(ctx, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		expand = setting == user.SensitiveExpand
+	}
+
+	quoted, err := s.quotedPosts(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]PostResponse, len(posts))
+	for i, p := range posts {
+		res[i] = PostResponse{
+			Post:           p,
+			Attachments:    attachments[p.ID],
+			Mentions:       mention.Entities(p.Content, mentioned[p.ID]),
+			RepostsCount:   reposts[p.ID],
+			Reactions:      reactions[p.ID].Counts,
+			ViewerReaction: reactions[p.ID].Viewer,
+			Poll:           polls[p.ID],
+			LinkPreview:    previews[p.ID],
+			Pinned:         pinned[p.ID],
+			Bookmarked:     bookmarked[p.ID],
+			Collapsed:      !expand && flagged(p),
+		}
+		if p.UserID == viewerID {
+			count := views[p.ID]
+			res[i].ViewsCount = &count
+		}
+		if markdown.HTMLRequested(ctx) {
+			res[i].ContentHTML = contentHTML(p, mentioned[p.ID])
+		}
+		if p.QuotePostID.Valid {
+			q, ok := quoted[p.QuotePostID.Int32]
+			if !ok {
+				q = &QuotedPost{ID: p.QuotePostID.Int32, Tombstone: true}
+			}
+			res[i].QuotedPost = q
+		}
+		if res[i].Attachments == nil {
+			res[i].Attachments = []media.AttachmentResponse{}
+		}
+	}
+	return res, nil
+}
+
+// viewCounts returns the view counts of the posts written by viewerID. Only
+// authors can see how often their posts were viewed.
+func (s *svc) viewCounts(ctx context.Context, posts []repo.Post, viewerID int32) (map[int32]int64, error) {
+	var ids []int32
+	for _, p := range posts {
+		if viewerID != 0 && p.UserID == viewerID {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.repo.ListViewCountsByPostIDs(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
-	return posts, nil
+	views := make(map[int32]int64, len(rows))
+	for _, row := range rows {
+		views[row.PostID] = row.ViewsCount
+	}
+	return views, nil
+}
+
+// quotedPosts loads the originals quoted by posts. Originals that are
+// missing, deleted, unpublished or no longer public are left out and render
+// as tombstones.
+func (s *svc) quotedPosts(ctx context.Context, posts []repo.Post) (map[int32]*QuotedPost, error) {
+	var ids []int32
+	for _, p := range posts {
+		if p.QuotePostID.Valid {
+			ids = append(ids, p.QuotePostID.Int32)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	originals, err := s.repo.ListPostsByIDsIncludingDeleted(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	quoted := make(map[int32]*QuotedPost, len(originals))
+	for _, o := range originals {
+		if o.DeletedAt.Valid || o.Status != StatusPublished || o.Visibility != VisibilityPublic {
+			continue
+		}
+		quoted[o.ID] = &QuotedPost{ID: o.ID, Post: &o}
+		if markdown.HTMLRequested(ctx) {
+			quoted[o.ID].ContentHTML = contentHTML(o, nil)
+		}
+	}
+	return quoted, nil
+}
+
+func (s *svc) toResponse(ctx context.Context, post repo.Post, viewerID int32) (PostResponse, error) {
+	res, err := s.ToResponses(ctx, []repo.Post{post}, viewerID)
+	if err != nil {
+		return PostResponse{}, err
+	}
+	return res[0], nil
+}
+
+// contentHTML falls back to rendering on read for posts written before
+// content_html existed.
+func contentHTML(p repo.Post, mentioned map[string]int32) string {
+	if p.ContentHtml == "" && p.Content != "" {
+		return markdown.Render(p.Content, mentioned)
+	}
+	return p.ContentHtml
+}
+
+// normalizeContentWarning trims the warning and checks its length.
+func normalizeContentWarning(contentWarning string) (string, error) {
+	contentWarning = strings.TrimSpace(contentWarning)
+	if utf8.RuneCountInString(contentWarning) > MaxContentWarningLength {
+		return "", ErrContentWarningTooLong
+	}
+	return contentWarning, nil
+}
+
+func validVisibility(visibility string) bool {
+	switch visibility {
+	case VisibilityPublic, VisibilityFollowers, VisibilityMentioned, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// resolveStatus defaults an empty status to "scheduled" when publishAt is
+// given and to "published" otherwise.
+func resolveStatus(status string, publishAt *time.Time) (string, pgtype.Timestamptz, error) {
+	now := time.Now()
+
+	if status == "" {
+		status = StatusPublished
+		if publishAt != nil {
+			status = StatusScheduled
+		}
+	}
+
+	switch status {
+	case StatusDraft:
+		return status, pgtype.Timestamptz{}, nil
+	case StatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return "", pgtype.Timestamptz{}, ErrInvalidPublishAt
+		}
+		return status, pgtype.Timestamptz{Time: *publishAt, Valid: true}, nil
+	case StatusPublished:
+		return status, pgtype.Timestamptz{Time: now, Valid: true}, nil
+	default:
+		return "", pgtype.Timestamptz{}, ErrInvalidStatus
+	}
 }
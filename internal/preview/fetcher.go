@@ -0,0 +1,264 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	MaxBodySize  = 1 << 20
+	MaxRedirects = 3
+	FetchTimeout = 5 * time.Second
+
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+	userAgent            = "Mozilla/5.0 (compatible; social-network-preview/1.0)"
+)
+
+var (
+	ErrBlockedAddress = errors.New("address is not publicly routable")
+	ErrUnsupportedURL = errors.New("unsupported url")
+	ErrTooManyHops    = errors.New("too many redirects")
+	ErrNotHTML        = errors.New("response is not html")
+	ErrNoMetadata     = errors.New("no preview metadata")
+)
+
+// blockedPrefixes are special-purpose ranges not covered by the netip
+// predicates used in isPublic. NAT64 and 6to4 are blocked as they can embed
+// private IPv4 addresses.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+type Metadata struct {
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// Fetcher downloads pages and reads their OpenGraph and Twitter card tags.
+// The address is checked when the connection is dialled, after DNS
+// resolution and on every redirect, so a hostname cannot be used to reach
+// private networks.
+type Fetcher struct {
+	client  *http.Client
+	allowed func(netip.Addr) bool
+}
+
+func NewFetcher() *Fetcher {
+	return newFetcher(isPublic, FetchTimeout)
+}
+
+func newFetcher(allowed func(netip.Addr) bool, timeout time.Duration) *Fetcher {
+	f := &Fetcher{allowed: allowed}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: f.control,
+	}
+
+	f.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                  nil,
+			DialContext:            dialer.DialContext,
+			TLSHandshakeTimeout:    timeout,
+			ResponseHeaderTimeout:  timeout,
+			MaxResponseHeaderBytes: 64 << 10,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > MaxRedirects {
+				return ErrTooManyHops
+			}
+			return checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+func (f *Fetcher) control(network, address string, _ syscall.RawConn) error {
+	if network != "tcp4" && network != "tcp6" {
+		return ErrBlockedAddress
+	}
+
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+
+	if !f.allowed(addr.Addr().Unmap()) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (Metadata, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Metadata{}, ErrUnsupportedURL
+	}
+
+	if err := checkURL(u); err != nil {
+		return Metadata{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Metadata{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return Metadata{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Metadata{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return Metadata{}, ErrNotHTML
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, MaxBodySize), contentType)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	meta := parse(body, resp.Request.URL)
+	if meta.Title == "" {
+		return Metadata{}, ErrNoMetadata
+	}
+	return meta, nil
+}
+
+// parse reads the metadata from the head of a document. OpenGraph tags are
+// preferred over Twitter cards, which are preferred over plain HTML.
+func parse(r io.Reader, base *url.URL) Metadata {
+	tags := make(map[string]string)
+	var title string
+
+	z := html.NewTokenizer(r)
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				break loop
+			case "title":
+				if z.Next() == html.TextToken && title == "" {
+					title = string(z.Text())
+				}
+			case "meta":
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						key = strings.ToLower(string(v))
+					case "content":
+						content = string(v)
+					}
+				}
+				if _, ok := tags[key]; key != "" && !ok {
+					tags[key] = content
+				}
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				break loop
+			}
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := strings.Join(strings.Fields(tags[k]), " "); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	meta := Metadata{
+		Title:       truncate(first("og:title", "twitter:title"), maxTitleLength),
+		Description: truncate(first("og:description", "twitter:description", "description"), maxDescriptionLength),
+		SiteName:    truncate(first("og:site_name"), maxTitleLength),
+	}
+	if meta.Title == "" {
+		meta.Title = truncate(strings.Join(strings.Fields(title), " "), maxTitleLength)
+	}
+
+	if image := first("og:image", "og:image:url", "og:image:secure_url", "twitter:image", "twitter:image:src"); image != "" {
+		if u, err := base.Parse(image); err == nil && checkURL(u) == nil {
+			meta.ImageURL = u.String()
+		}
+	}
+	return meta
+}
+
+func checkURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return ErrUnsupportedURL
+	}
+	return nil
+}
+
+func isPublic(addr netip.Addr) bool {
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,89 @@
+package preview
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// CacheTTL is how long a fetched preview is reused before a new post
+	// linking to the same URL triggers a refetch.
+	CacheTTL = 7 * 24 * time.Hour
+
+	maxURLLength = 2048
+)
+
+var urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]{}"'` + "`" + `]+`)
+
+type Service interface {
+	SetPostLink(ctx context.Context, postID int32, content string) error
+	ListByPostIDs(ctx context.Context, postIDs []int32) (map[int32]*LinkPreview, error)
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// SetPostLink links the post to the preview of the first URL in content and
+// queues the preview for fetching unless a fresh one is cached.
+func (s *svc) SetPostLink(ctx context.Context, postID int32, content string) error {
+	u := FirstURL(content)
+	if u == "" {
+		return s.repo.DeletePostLink(ctx, postID)
+	}
+
+	return s.repo.SetPostLink(ctx, repo.SetPostLinkParams{
+		Url:         u,
+		StaleBefore: pgtype.Timestamptz{Time: time.Now().Add(-CacheTTL), Valid: true},
+		PostID:      postID,
+	})
+}
+
+// ListByPostIDs returns the previews that have been fetched for the posts,
+// keyed by post id.
+func (s *svc) ListByPostIDs(ctx context.Context, postIDs []int32) (map[int32]*LinkPreview, error) {
+	rows, err := s.repo.ListLinkPreviewsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int32]*LinkPreview, len(rows))
+	for _, row := range rows {
+		res[row.PostID] = &LinkPreview{
+			URL:         row.Url,
+			Title:       row.Title,
+			Description: row.Description,
+			ImageURL:    row.ImageUrl,
+			SiteName:    row.SiteName,
+		}
+	}
+	return res, nil
+}
+
+// FirstURL returns the first http or https URL in text, without trailing
+// punctuation, or "" if there is none.
+func FirstURL(text string) string {
+	for _, m := range urlPattern.FindAllString(text, -1) {
+		m = strings.TrimRight(m, ".,;:!?*_~")
+		if len(m) > maxURLLength {
+			continue
+		}
+
+		u, err := url.Parse(m)
+		if err != nil || checkURL(u) != nil {
+			continue
+		}
+		return u.String()
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package preview
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	StatusReady  = "ready"
+	StatusFailed = "failed"
+
+	fetchBatchSize = 10
+	// claimTimeout releases claims of a worker that died mid-fetch.
+	claimTimeout = time.Minute
+)
+
+// Worker fetches queued link previews in the background. Rows are claimed
+// with FOR UPDATE SKIP LOCKED, so several API instances can run it side by
+// side.
+type Worker struct {
+	repo     repo.Querier
+	fetcher  *Fetcher
+	interval time.Duration
+}
+
+func NewWorker(repo repo.Querier, fetcher *Fetcher, interval time.Duration) *Worker {
+	return &Worker{repo: repo, fetcher: fetcher, interval: interval}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.fetchPending(ctx)
+		}
+	}
+}
+
+func (w *Worker) fetchPending(ctx context.Context) {
+	for {
+		urls, err := w.repo.ClaimPendingLinkPreviews(ctx, repo.ClaimPendingLinkPreviewsParams{
+			ClaimExpiredBefore: pgtype.Timestamptz{Time: time.Now().Add(-claimTimeout), Valid: true},
+			Limit:              fetchBatchSize,
+		})
+		if err != nil {
+			slog.Error("failed to claim link previews", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, u := range urls {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.fetch(ctx, u)
+			}()
+		}
+		wg.Wait()
+
+		if len(urls) < fetchBatchSize {
+			return
+		}
+	}
+}
+
+func (w *Worker) fetch(ctx context.Context, u string) {
+	params := repo.SaveLinkPreviewParams{Url: u, Status: StatusReady}
+
+	meta, err := w.fetcher.Fetch(ctx, u)
+	if err != nil {
+		slog.Warn("failed to fetch link preview", "error", err, "url", u)
+		params.Status = StatusFailed
+	} else {
+		params.Title = meta.Title
+		params.Description = meta.Description
+		params.ImageUrl = meta.ImageURL
+		params.SiteName = meta.SiteName
+	}
+
+	if err := w.repo.SaveLinkPreview(ctx, params); err != nil {
+		slog.Error("failed to save link preview", "error", err, "url", u)
+	}
+}
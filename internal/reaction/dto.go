@@ -0,0 +1,12 @@
+package reaction
+
+type ReactRequest struct {
+	Type string `json:"type"`
+}
+
+// Summary aggregates the reactions to a post or comment. Counts has an entry
+// for every configured type, and Viewer is the viewer's own reaction, or nil.
+type Summary struct {
+	Counts map[string]int64
+	Viewer *string
+}
@@ -0,0 +1,173 @@
+package reaction
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) ListTypes(w http.ResponseWriter, r *http.Request) {
+	json.Write(w, http.StatusOK, h.service.Types())
+}
+
+func (h *Handler) ReactToPost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	var req ReactRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read reaction request", "error", err)
+		http.Error(w, "failed to read reaction request", http.StatusBadRequest)
+		return
+	}
+
+	reaction, err := h.service.ReactToPost(r.Context(), int32(postID), uid, req)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrInvalidType:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to react to post", "error", err, "post_id", postID, "user_id", uid)
+			http.Error(w, "failed to react to post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, reaction)
+}
+
+func (h *Handler) RemovePostReaction(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.RemovePostReaction(r.Context(), int32(postID), uid)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to remove post reaction", "error", err, "post_id", postID, "user_id", uid)
+			http.Error(w, "failed to remove reaction", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListPostReactions(w http.ResponseWriter, r *http.Request) {
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListPostReactions(r.Context(), int32(postID), auth.UserIDFromContext(r.Context()), r.URL.Query().Get("type"), p)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrInvalidType:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to list reactions", "error", err, "post_id", postID)
+			http.Error(w, "failed to list reactions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, page)
+}
+
+func (h *Handler) ReactToComment(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	var req ReactRequest
+	if err := json.Read(r, &req); err != nil {
+		slog.Error("failed to read reaction request", "error", err)
+		http.Error(w, "failed to read reaction request", http.StatusBadRequest)
+		return
+	}
+
+	reaction, err := h.service.ReactToComment(r.Context(), int32(id), uid, req)
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		case ErrInvalidType:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to react to comment", "error", err, "comment_id", id, "user_id", uid)
+			http.Error(w, "failed to react to comment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, reaction)
+}
+
+func (h *Handler) RemoveCommentReaction(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid comment id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.RemoveCommentReaction(r.Context(), int32(id), uid)
+	if err != nil {
+		switch err {
+		case ErrCommentNotFound:
+			http.Error(w, "comment not found", http.StatusNotFound)
+		default:
+			slog.Error("failed to remove comment reaction", "error", err, "comment_id", id, "user_id", uid)
+			http.Error(w, "failed to remove reaction", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
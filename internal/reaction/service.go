@@ -0,0 +1,266 @@
+package reaction
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Like is the reaction stored by the like endpoints. It is always part of
+// the configured set.
+const Like = "like"
+
+// DefaultTypes is used when no reaction types are configured.
+var DefaultTypes = []string{Like, "love", "laugh", "wow", "sad", "angry"}
+
+var (
+	ErrInvalidType     = errors.New("invalid reaction type")
+	ErrPostNotFound    = errors.New("post not found")
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+type Service interface {
+	Types() []string
+	ReactToPost(ctx context.Context, postID, userID int32, req ReactRequest) (repo.Like, error)
+	RemovePostReaction(ctx context.Context, postID, userID int32) error
+	ListPostReactions(ctx context.Context, postID, viewerID int32, reactionType string, p pagination.Params) (pagination.Page[repo.Like], error)
+	ReactToComment(ctx context.Context, commentID, userID int32, req ReactRequest) (repo.CommentLike, error)
+	RemoveCommentReaction(ctx context.Context, commentID, userID int32) error
+	ListByPostIDs(ctx context.Context, postIDs []int32, viewerID int32) (map[int32]Summary, error)
+	ListByCommentIDs(ctx context.Context, commentIDs []int32, viewerID int32) (map[int32]Summary, error)
+}
+
+type svc struct {
+	repo  repo.Querier
+	types []string
+}
+
+// NewService returns a service that accepts the given reaction types. Like is
+// added when missing so the like endpoints keep working.
+func NewService(repo repo.Querier, types []string) Service {
+	var allowed []string
+	for _, t := range types {
+		if t != "" && !slices.Contains(allowed, t) {
+			allowed = append(allowed, t)
+		}
+	}
+	if len(allowed) == 0 {
+		allowed = DefaultTypes
+	}
+	if !slices.Contains(allowed, Like) {
+		allowed = append([]string{Like}, allowed...)
+	}
+	return &svc{repo: repo, types: allowed}
+}
+
+func (s *svc) Types() []string {
+	return s.types
+}
+
+// ReactToPost sets the reaction of the user to a post, replacing any earlier
+// one. The time of the first reaction is kept, so changing a reaction does
+// not move the post up in the user's likes.
+func (s *svc) ReactToPost(ctx context.Context, postID, userID int32, req ReactRequest) (repo.Like, error) {
+	if !slices.Contains(s.types, req.Type) {
+		return repo.Like{}, ErrInvalidType
+	}
+
+	if err := s.checkPostVisible(ctx, postID, userID); err != nil {
+		return repo.Like{}, err
+	}
+
+	return s.repo.ReactToPost(ctx, repo.ReactToPostParams{
+		UserID:   userID,
+		PostID:   postID,
+		Reaction: req.Type,
+	})
+}
+
+func (s *svc) RemovePostReaction(ctx context.Context, postID, userID int32) error {
+	if err := s.checkPostVisible(ctx, postID, userID); err != nil {
+		return err
+	}
+
+	return s.repo.RemovePostReaction(ctx, repo.RemovePostReactionParams{
+		UserID: userID,
+		PostID: postID,
+	})
+}
+
+// ListPostReactions lists who reacted to a post, newest first. An empty
+// reactionType lists every reaction.
+func (s *svc) ListPostReactions(ctx context.Context, postID, viewerID int32, reactionType string, p pagination.Params) (pagination.Page[repo.Like], error) {
+	var reaction pgtype.Text
+	if reactionType != "" {
+		if !slices.Contains(s.types, reactionType) {
+			return pagination.Page[repo.Like]{}, ErrInvalidType
+		}
+		reaction = pgtype.Text{String: reactionType, Valid: true}
+	}
+
+	if err := s.checkPostVisible(ctx, postID, viewerID); err != nil {
+		return pagination.Page[repo.Like]{}, err
+	}
+
+	reactions, err := s.repo.ListPostReactions(ctx, repo.ListPostReactionsParams{
+		PostID:     postID,
+		Reaction:   reaction,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorID:   p.CursorID(),
+		Limit:      p.Fetch(),
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[repo.Like]{}, err
+	}
+
+	reactions, c := pagination.Paginate(reactions, p, func(l repo.Like) pagination.Key {
+		return pagination.Key{Time: l.CreatedAt.Time, ID: l.ID}
+	})
+	return pagination.NewPage(reactions, c), nil
+}
+
+// ReactToComment sets the reaction of the user to a comment, replacing any
+// earlier one. The time of the first reaction is kept.
+func (s *svc) ReactToComment(ctx context.Context, commentID, userID int32, req ReactRequest) (repo.CommentLike, error) {
+	if !slices.Contains(s.types, req.Type) {
+		return repo.CommentLike{}, ErrInvalidType
+	}
+
+	if err := s.checkCommentVisible(ctx, commentID, userID); err != nil {
+		return repo.CommentLike{}, err
+	}
+
+	return s.repo.ReactToComment(ctx, repo.ReactToCommentParams{
+		UserID:    userID,
+		CommentID: commentID,
+		Reaction:  req.Type,
+	})
+}
+
+func (s *svc) RemoveCommentReaction(ctx context.Context, commentID, userID int32) error {
+	if err := s.checkCommentVisible(ctx, commentID, userID); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveCommentReaction(ctx, repo.RemoveCommentReactionParams{
+		UserID:    userID,
+		CommentID: commentID,
+	})
+}
+
+// ListByPostIDs returns the reaction summary of each post. The viewer's own
+// reaction is only looked up for signed-in viewers.
+func (s *svc) ListByPostIDs(ctx context.Context, postIDs []int32, viewerID int32) (map[int32]Summary, error) {
+	counts, err := s.repo.CountReactionsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := s.newSummaries(postIDs)
+	for _, c := range counts {
+		s.addCount(summaries[c.PostID], c.Reaction, c.Count)
+	}
+
+	if viewerID != 0 {
+		rows, err := s.repo.ListViewerPostReactions(ctx, repo.ListViewerPostReactionsParams{
+			UserID:  viewerID,
+			PostIds: postIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			s.setViewer(summaries, row.PostID, row.Reaction)
+		}
+	}
+	return summaries, nil
+}
+
+// ListByCommentIDs is ListByPostIDs for comments.
+func (s *svc) ListByCommentIDs(ctx context.Context, commentIDs []int32, viewerID int32) (map[int32]Summary, error) {
+	counts, err := s.repo.CountReactionsByCommentIDs(ctx, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := s.newSummaries(commentIDs)
+	for _, c := range counts {
+		s.addCount(summaries[c.CommentID], c.Reaction, c.Count)
+	}
+
+	if viewerID != 0 {
+		rows, err := s.repo.ListViewerCommentReactions(ctx, repo.ListViewerCommentReactionsParams{
+			UserID:     viewerID,
+			CommentIds: commentIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			s.setViewer(summaries, row.CommentID, row.Reaction)
+		}
+	}
+	return summaries, nil
+}
+
+func (s *svc) newSummaries(ids []int32) map[int32]Summary {
+	summaries := make(map[int32]Summary, len(ids))
+	for _, id := range ids {
+		counts := make(map[string]int64, len(s.types))
+		for _, t := range s.types {
+			counts[t] = 0
+		}
+		summaries[id] = Summary{Counts: counts}
+	}
+	return summaries
+}
+
+// addCount skips reactions that were removed from the configured set after
+// users had chosen them.
+func (s *svc) addCount(summary Summary, reaction string, count int64) {
+	if _, ok := summary.Counts[reaction]; ok {
+		summary.Counts[reaction] = count
+	}
+}
+
+func (s *svc) setViewer(summaries map[int32]Summary, id int32, reaction string) {
+	summary := summaries[id]
+	if _, ok := summary.Counts[reaction]; ok {
+		summary.Viewer = &reaction
+		summaries[id] = summary
+	}
+}
+
+func (s *svc) checkPostVisible(ctx context.Context, postID, viewerID int32) error {
+	visible, err := s.repo.IsPostVisible(ctx, repo.IsPostVisibleParams{
+		PostID:   postID,
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+func (s *svc) checkCommentVisible(ctx context.Context, commentID, viewerID int32) error {
+	visible, err := s.repo.IsCommentVisible(ctx, repo.IsCommentVisibleParams{
+		CommentID: commentID,
+		ViewerID:  viewerID,
+	})
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrCommentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repost
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RepostPost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	rp, err := h.service.RepostPost(r.Context(), uid, int32(postID))
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			http.Error(w, "post not found", http.StatusNotFound)
+		case ErrNotRepostable:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrAlreadyReposted:
+			http.Error(w, "already reposted this post", http.StatusConflict)
+		default:
+			slog.Error("failed to repost post", "error", err)
+			http.Error(w, "failed to repost post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusCreated, rp)
+}
+
+func (h *Handler) UndoRepost(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+
+	postIDStr := chi.URLParam(r, "post_id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.UndoRepost(r.Context(), uid, int32(postID))
+	if err != nil {
+		switch err {
+		case ErrNotReposted:
+			http.Error(w, "post is not reposted", http.StatusNotFound)
+		default:
+			slog.Error("failed to undo repost", "error", err)
+			http.Error(w, "failed to undo repost", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
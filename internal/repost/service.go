@@ -0,0 +1,66 @@
+package repost
+
+import (
+	"context"
+	"errors"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+)
+
+var (
+	ErrAlreadyReposted = errors.New("already reposted this post")
+	ErrNotReposted     = errors.New("post is not reposted")
+	ErrPostNotFound    = errors.New("post not found")
+	ErrNotRepostable   = errors.New("only public posts can be reposted")
+)
+
+type Service interface {
+	RepostPost(ctx context.Context, userID, postID int32) (repo.Repost, error)
+	UndoRepost(ctx context.Context, userID, postID int32) error
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+func (s *svc) RepostPost(ctx context.Context, userID, postID int32) (repo.Repost, error) {
+	p, err := s.repo.FindVisiblePostByID(ctx, repo.FindVisiblePostByIDParams{
+		ID:       postID,
+		ViewerID: userID,
+	})
+	if err != nil || p.Status != post.StatusPublished {
+		return repo.Repost{}, ErrPostNotFound
+	}
+
+	if p.Visibility != post.VisibilityPublic {
+		return repo.Repost{}, ErrNotRepostable
+	}
+
+	r, err := s.repo.RepostPost(ctx, repo.RepostPostParams{
+		UserID: userID,
+		PostID: postID,
+	})
+	if err != nil {
+		return repo.Repost{}, ErrAlreadyReposted
+	}
+	return r, nil
+}
+
+func (s *svc) UndoRepost(ctx context.Context, userID, postID int32) error {
+	n, err := s.repo.UndoRepost(ctx, repo.UndoRepostParams{
+		UserID: userID,
+		PostID: postID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotReposted
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package search
+
+import (
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+)
+
+// PostResult is a matching post. Snippet is an HTML-escaped excerpt of the
+// content with the matched words wrapped in <mark> tags, as in CommentResult.
+type PostResult struct {
+	post.PostResponse
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+type CommentResult struct {
+	repo.Comment
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+type UserResult struct {
+	ID     int32   `json:"id"`
+	Name   string  `json:"name"`
+	Handle string  `json:"handle"`
+	Score  float64 `json:"score"`
+}
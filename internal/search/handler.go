@@ -0,0 +1,57 @@
+package search
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/etherealsense/social-network/pkg/textsearch"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Search handles GET /search?q=&type=posts|comments|users&lang=. The type
+// defaults to posts and lang to english.
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+	language := r.URL.Query().Get("lang")
+
+	var res any
+	switch r.URL.Query().Get("type") {
+	case "", TypePosts:
+		res, err = h.service.SearchPosts(r.Context(), query, language, uid, p)
+	case TypeComments:
+		res, err = h.service.SearchComments(r.Context(), query, language, uid, p)
+	case TypeUsers:
+		res, err = h.service.SearchUsers(r.Context(), query, uid, p)
+	default:
+		err = ErrInvalidType
+	}
+	if err != nil {
+		switch err {
+		case ErrInvalidType, ErrInvalidLanguage, ErrQueryTooLong, textsearch.ErrEmptyQuery:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to search", "error", err, "user_id", uid)
+			http.Error(w, "failed to search", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.Write(w, http.StatusOK, res)
+}
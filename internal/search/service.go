@@ -0,0 +1,190 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/internal/post"
+	"github.com/etherealsense/social-network/pkg/pagination"
+	"github.com/etherealsense/social-network/pkg/textsearch"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	TypePosts    = "posts"
+	TypeComments = "comments"
+	TypeUsers    = "users"
+)
+
+const MaxQueryLength = 200
+
+var (
+	ErrInvalidType     = errors.New("type must be one of posts, comments or users")
+	ErrInvalidLanguage = errors.New("invalid language")
+	ErrQueryTooLong    = errors.New("query must be at most 200 characters")
+)
+
+// Service searches posts, comments and users. Results are ranked by
+// relevance with a bonus for recent posts and comments, and only include
+// what viewerID may read and is not blocked from or by. Posts and comments of
+// users viewerID muted are left out, but the users themselves are still
+// found. The bonus is computed as of the time of the first page, so results
+// keep their rank across pages.
+type Service interface {
+	SearchPosts(ctx context.Context, query, language string, viewerID int32, p pagination.Params) (pagination.Page[PostResult], error)
+	SearchComments(ctx context.Context, query, language string, viewerID int32, p pagination.Params) (pagination.Page[CommentResult], error)
+	SearchUsers(ctx context.Context, query string, viewerID int32, p pagination.Params) (pagination.Page[UserResult], error)
+}
+
+type svc struct {
+	repo  repo.Querier
+	posts post.Service
+}
+
+func NewService(repo repo.Querier, posts post.Service) Service {
+	return &svc{repo: repo, posts: posts}
+}
+
+func (s *svc) SearchPosts(ctx context.Context, query, language string, viewerID int32, p pagination.Params) (pagination.Page[PostResult], error) {
+	tsquery, language, err := parse(query, language)
+	if err != nil {
+		return pagination.Page[PostResult]{}, err
+	}
+
+	asOf := p.AsOf()
+	rows, err := s.repo.SearchPosts(ctx, repo.SearchPostsParams{
+		Language:    language,
+		Query:       tsquery,
+		AsOf:        pgtype.Timestamptz{Time: asOf, Valid: true},
+		ViewerID:    viewerID,
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[PostResult]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.SearchPostsRow) pagination.Key {
+		return pagination.Key{Time: asOf, Score: r.Score, ID: r.Post.ID}
+	})
+
+	posts := make([]repo.Post, len(rows))
+	for i, row := range rows {
+		posts[i] = row.Post
+	}
+
+	hydrated, err := s.posts.ToResponses(ctx, posts, viewerID)
+	if err != nil {
+		return pagination.Page[PostResult]{}, err
+	}
+
+	res := make([]PostResult, len(rows))
+	for i, row := range rows {
+		res[i] = PostResult{
+			PostResponse: hydrated[i],
+			Snippet:      row.Snippet,
+			Score:        row.Score,
+		}
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+func (s *svc) SearchComments(ctx context.Context, query, language string, viewerID int32, p pagination.Params) (pagination.Page[CommentResult], error) {
+	tsquery, language, err := parse(query, language)
+	if err != nil {
+		return pagination.Page[CommentResult]{}, err
+	}
+
+	asOf := p.AsOf()
+	rows, err := s.repo.SearchComments(ctx, repo.SearchCommentsParams{
+		Language:    language,
+		Query:       tsquery,
+		AsOf:        pgtype.Timestamptz{Time: asOf, Valid: true},
+		ViewerID:    viewerID,
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[CommentResult]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.SearchCommentsRow) pagination.Key {
+		return pagination.Key{Time: asOf, Score: r.Score, ID: r.Comment.ID}
+	})
+
+	res := make([]CommentResult, len(rows))
+	for i, row := range rows {
+		res[i] = CommentResult{
+			Comment: row.Comment,
+			Snippet: row.Snippet,
+			Score:   row.Score,
+		}
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+// SearchUsers matches names and handles without stemming, so the language
+// does not apply.
+func (s *svc) SearchUsers(ctx context.Context, query string, viewerID int32, p pagination.Params) (pagination.Page[UserResult], error) {
+	tsquery, _, err := parse(query, "")
+	if err != nil {
+		return pagination.Page[UserResult]{}, err
+	}
+
+	rows, err := s.repo.SearchUsers(ctx, repo.SearchUsersParams{
+		Query:       tsquery,
+		ViewerID:    viewerID,
+		CursorScore: p.CursorScore(),
+		Backward:    p.Backward(),
+		CursorID:    p.CursorID(),
+		Limit:       p.Fetch(),
+		Offset:      p.Offset,
+	})
+	if err != nil {
+		return pagination.Page[UserResult]{}, err
+	}
+
+	rows, c := pagination.Paginate(rows, p, func(r repo.SearchUsersRow) pagination.Key {
+		return pagination.Key{Score: r.Score, ID: r.ID}
+	})
+
+	res := make([]UserResult, len(rows))
+	for i, row := range rows {
+		res[i] = UserResult{
+			ID:     row.ID,
+			Name:   row.Name,
+			Handle: row.Handle,
+			Score:  row.Score,
+		}
+	}
+	return pagination.NewPage(res, c), nil
+}
+
+// parse validates the input and returns it in tsquery syntax along with the
+// text search configuration to parse it with.
+func parse(query, language string) (string, string, error) {
+	if utf8.RuneCountInString(query) > MaxQueryLength {
+		return "", "", ErrQueryTooLong
+	}
+
+	if language == "" {
+		language = textsearch.DefaultLanguage
+	}
+	if !textsearch.ValidLanguage(language) {
+		return "", "", ErrInvalidLanguage
+	}
+
+	tsquery, err := textsearch.ParseQuery(query)
+	if err != nil {
+		return "", "", err
+	}
+	return tsquery, language, nil
+}
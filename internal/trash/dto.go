@@ -0,0 +1,29 @@
+package trash
+
+import (
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+// Trash item types.
+const (
+	TypePost    = "post"
+	TypeComment = "comment"
+)
+
+// TrashItem is a trashed post or comment. Type tells which of Post and
+// Comment is set.
+type TrashItem struct {
+	Type    string        `json:"type"`
+	Post    *repo.Post    `json:"post,omitempty"`
+	Comment *repo.Comment `json:"comment,omitempty"`
+}
+
+// key sorts items by deletion time, then by type, since a post and a comment
+// may share an id.
+func (i TrashItem) key() pagination.Key {
+	if i.Post != nil {
+		return pagination.Key{Time: i.Post.DeletedAt.Time, Type: TypePost, ID: i.Post.ID}
+	}
+	return pagination.Key{Time: i.Comment.DeletedAt.Time, Type: TypeComment, ID: i.Comment.ID}
+}
@@ -0,0 +1,36 @@
+package trash
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/etherealsense/social-network/internal/auth"
+	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	uid := auth.UserIDFromContext(r.Context())
+	p, err := pagination.Parse(r)
+	if err != nil {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	trash, err := h.service.ListTrash(r.Context(), uid, p)
+	if err != nil {
+		slog.Error("failed to list trash", "error", err, "user_id", uid)
+		http.Error(w, "failed to list trash", http.StatusInternalServerError)
+		return
+	}
+
+	json.Write(w, http.StatusOK, trash)
+}
@@ -0,0 +1,58 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Retention is how long soft-deleted posts and comments stay restorable.
+const Retention = 30 * 24 * time.Hour
+
+// Purger periodically hard-deletes posts and comments that have been in the
+// trash for longer than Retention.
+type Purger struct {
+	repo     repo.Querier
+	interval time.Duration
+}
+
+func NewPurger(repo repo.Querier, interval time.Duration) *Purger {
+	return &Purger{repo: repo, interval: interval}
+}
+
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-Retention), Valid: true}
+
+	comments, err := p.repo.PurgeDeletedComments(ctx, cutoff)
+	if err != nil {
+		slog.Error("failed to purge deleted comments", "error", err)
+		return
+	}
+
+	posts, err := p.repo.PurgeDeletedPosts(ctx, cutoff)
+	if err != nil {
+		slog.Error("failed to purge deleted posts", "error", err)
+		return
+	}
+
+	if comments > 0 || posts > 0 {
+		slog.Info("purged trash", "posts", posts, "comments", comments)
+	}
+}
@@ -0,0 +1,97 @@
+package trash
+
+import (
+	"context"
+
+	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
+	"github.com/etherealsense/social-network/pkg/pagination"
+)
+
+type Service interface {
+	ListTrash(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[TrashItem], error)
+}
+
+type svc struct {
+	repo repo.Querier
+}
+
+func NewService(repo repo.Querier) Service {
+	return &svc{repo: repo}
+}
+
+// ListTrash lists the trashed posts and comments of the user in one list,
+// most recently deleted first. Each table is queried for a full page and the
+// two are merged, so with an offset both are read from the start.
+func (s *svc) ListTrash(ctx context.Context, userID int32, p pagination.Params) (pagination.Page[TrashItem], error) {
+	limit := p.Offset + p.Fetch()
+
+	posts, err := s.repo.ListTrashedPostsByUserID(ctx, repo.ListTrashedPostsByUserIDParams{
+		UserID:     userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorType: p.CursorType(),
+		CursorID:   p.CursorID(),
+		Limit:      limit,
+	})
+	if err != nil {
+		return pagination.Page[TrashItem]{}, err
+	}
+
+	comments, err := s.repo.ListTrashedCommentsByUserID(ctx, repo.ListTrashedCommentsByUserIDParams{
+		UserID:     userID,
+		CursorTime: p.CursorTime(),
+		Backward:   p.Backward(),
+		CursorType: p.CursorType(),
+		CursorID:   p.CursorID(),
+		Limit:      limit,
+	})
+	if err != nil {
+		return pagination.Page[TrashItem]{}, err
+	}
+
+	items := merge(posts, comments, p.Backward())
+	items = items[min(int(p.Offset), len(items)):]
+
+	items, c := pagination.Paginate(items, p, TrashItem.key)
+	return pagination.NewPage(items, c), nil
+}
+
+// merge merges posts and comments, both in list order, into one list in list
+// order: by deletion time, type and id descending, or ascending when paging
+// backward.
+func merge(posts []repo.Post, comments []repo.Comment, backward bool) []TrashItem {
+	items := make([]TrashItem, 0, len(posts)+len(comments))
+	for len(posts) > 0 || len(comments) > 0 {
+		if len(comments) == 0 {
+			items = append(items, TrashItem{Type: TypePost, Post: &posts[0]})
+			posts = posts[1:]
+			continue
+		}
+		if len(posts) == 0 {
+			items = append(items, TrashItem{Type: TypeComment, Comment: &comments[0]})
+			comments = comments[1:]
+			continue
+		}
+
+		post := TrashItem{Type: TypePost, Post: &posts[0]}
+		comment := TrashItem{Type: TypeComment, Comment: &comments[0]}
+		if later(post.key(), comment.key()) != backward {
+			items = append(items, post)
+			posts = posts[1:]
+		} else {
+			items = append(items, comment)
+			comments = comments[1:]
+		}
+	}
+	return items
+}
+
+func later(a, b pagination.Key) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.After(b.Time)
+	}
+	if a.Type != b.Type {
+		return a.Type > b.Type
+	}
+	return a.ID > b.ID
+}
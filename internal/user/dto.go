@@ -1,13 +1,23 @@
 package user
 
 type UserResponse struct {
-	ID    int32  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID               int32  `json:"id"`
+	Name             string `json:"name"`
+	Handle           string `json:"handle"`
+	Email            string `json:"email"`
+	MentionPolicy    string `json:"mention_policy"`
+	SensitiveContent string `json:"sensitive_content"`
+	HideLikes        bool   `json:"hide_likes"`
+	FeedRanking      string `json:"feed_ranking"`
 }
 
 type UpdateUserRequest struct {
-	Name     *string `json:"name"`
-	Email    *string `json:"email"`
-	Password *string `json:"password"`
+	Name             *string `json:"name"`
+	Handle           *string `json:"handle"`
+	Email            *string `json:"email"`
+	Password         *string `json:"password"`
+	MentionPolicy    *string `json:"mention_policy"`
+	SensitiveContent *string `json:"sensitive_content"`
+	HideLikes        *bool   `json:"hide_likes"`
+	FeedRanking      *string `json:"feed_ranking"`
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/etherealsense/social-network/internal/auth"
 	"github.com/etherealsense/social-network/pkg/json"
+	"github.com/etherealsense/social-network/pkg/validator"
 )
 
 type Handler struct {
@@ -40,8 +41,15 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.UpdateUser(r.Context(), userID, req)
 	if err != nil {
-		slog.Error("failed to update user", "error", err, "user_id", userID)
-		http.Error(w, "failed to update user", http.StatusInternalServerError)
+		switch err {
+		case ErrUserAlreadyExists:
+			http.Error(w, "user already exists", http.StatusConflict)
+		case ErrInvalidMentionPolicy, ErrInvalidSensitive, ErrInvalidFeedRanking, validator.ErrHandleEmpty, validator.ErrHandleInvalid:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to update user", "error", err, "user_id", userID)
+			http.Error(w, "failed to update user", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -3,16 +3,42 @@ package user
 import (
 	"context"
 	"errors"
+	"strings"
 
 	repo "github.com/etherealsense/social-network/internal/adapter/postgresql/sqlc"
 	"github.com/etherealsense/social-network/pkg/crypto"
+	"github.com/etherealsense/social-network/pkg/database"
 	"github.com/etherealsense/social-network/pkg/validator"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Mention policies decide who may mention a user.
+const (
+	MentionsEveryone  = "everyone"
+	MentionsFollowing = "following"
+	MentionsNobody    = "nobody"
+)
+
+// Sensitive content settings decide whether posts with a content warning or
+// sensitive media are collapsed for the user.
+const (
+	SensitiveHide   = "hide"
+	SensitiveExpand = "expand"
+)
+
+// Feed rankings decide how the home feed is ordered by default.
+const (
+	FeedChronological = "chronological"
+	FeedWeighted      = "weighted"
+	FeedDecayed       = "decayed"
+)
+
 var (
-	ErrUserAlreadyExists = errors.New("user already exists")
-	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidMentionPolicy = errors.New("mention_policy must be one of everyone, following or nobody")
+	ErrInvalidSensitive     = errors.New("sensitive_content must be one of hide or expand")
+	ErrInvalidFeedRanking   = errors.New("feed_ranking must be one of chronological, weighted or decayed")
 )
 
 type Service interface {
@@ -40,9 +66,14 @@ func (s *svc) FindUserByID(ctx context.Context, id int32) (UserResponse, error)
 	}
 
 	return UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:               user.ID,
+		Name:             user.Name,
+		Handle:           user.Handle,
+		Email:            user.Email,
+		MentionPolicy:    user.MentionPolicy,
+		SensitiveContent: user.SensitiveContent,
+		HideLikes:        user.HideLikes,
+		FeedRanking:      user.FeedRanking,
 	}, nil
 }
 
@@ -81,5 +112,53 @@ func (s *svc) UpdateUser(ctx context.Context, id int32, req UpdateUserRequest) (
 		params.Password = pgtype.Text{String: hashedPassword, Valid: true}
 	}
 
-	return s.repo.UpdateUser(ctx, params)
+	if req.Handle != nil {
+		handle := strings.ToLower(*req.Handle)
+		err = validator.ValidateHandle(handle)
+		if err != nil {
+			return repo.UpdateUserRow{}, err
+		}
+		params.Handle = pgtype.Text{String: handle, Valid: true}
+	}
+
+	if req.MentionPolicy != nil {
+		switch *req.MentionPolicy {
+		case MentionsEveryone, MentionsFollowing, MentionsNobody:
+		default:
+			return repo.UpdateUserRow{}, ErrInvalidMentionPolicy
+		}
+		params.MentionPolicy = pgtype.Text{String: *req.MentionPolicy, Valid: true}
+	}
+
+	if req.SensitiveContent != nil {
+		switch *req.SensitiveContent {
+		case SensitiveHide, SensitiveExpand:
+		default:
+			return repo.UpdateUserRow{}, ErrInvalidSensitive
+		}
+		params.SensitiveContent = pgtype.Text{String: *req.SensitiveContent, Valid: true}
+	}
+
+	if req.HideLikes != nil {
+		params.HideLikes = pgtype.Bool{Bool: *req.HideLikes, Valid: true}
+	}
+
+	if req.FeedRanking != nil {
+		switch *req.FeedRanking {
+		case FeedChronological, FeedWeighted, FeedDecayed:
+		default:
+			return repo.UpdateUserRow{}, ErrInvalidFeedRanking
+		}
+		params.FeedRanking = pgtype.Text{String: *req.FeedRanking, Valid: true}
+	}
+
+	user, err := s.repo.UpdateUser(ctx, params)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return repo.UpdateUserRow{}, ErrUserAlreadyExists
+		}
+		return repo.UpdateUserRow{}, err
+	}
+
+	return user, nil
 }
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// Transactor runs a function in a database transaction.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DB is the connection the queries run on. Inside WithTx it runs them in the
+// transaction carried by the context, so services built on the same queries
+// take part in the caller's transaction without being handed it.
+type DB struct {
+	pool *pgxpool.Pool
+}
+
+func NewDB(pool *pgxpool.Pool) *DB {
+	return &DB{pool: pool}
+}
+
+// WithTx runs fn in one transaction, committed when fn returns nil and
+// rolled back otherwise. A nested call joins the outer transaction.
+func (db *DB) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (db *DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+	return db.pool.Exec(ctx, sql, args...)
+}
+
+func (db *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+	return db.pool.Query(ctx, sql, args...)
+}
+
+func (db *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return db.pool.QueryRow(ctx, sql, args...)
+}
@@ -0,0 +1,125 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// maxCells bounds the LCS table so that diffing two huge texts cannot eat
+// the server's memory; past it the whole middle section is reported as
+// replaced.
+const maxCells = 4_000_000
+
+type Op struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Words returns a word-level diff that turns a into b. Whitespace runs are
+// kept as separate tokens so that joining the ops reproduces both texts.
+func Words(a, b string) []Op {
+	at, bt := tokenize(a), tokenize(b)
+
+	prefix := 0
+	for prefix < len(at) && prefix < len(bt) && at[prefix] == bt[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(at)-prefix && suffix < len(bt)-prefix &&
+		at[len(at)-1-suffix] == bt[len(bt)-1-suffix] {
+		suffix++
+	}
+
+	var ops []Op
+	ops = appendOp(ops, OpEqual, at[:prefix]...)
+	ops = append(ops, lcs(at[prefix:len(at)-suffix], bt[prefix:len(bt)-suffix])...)
+	ops = appendOp(ops, OpEqual, at[len(at)-suffix:]...)
+
+	return merge(ops)
+}
+
+func lcs(a, b []string) []Op {
+	if len(a)*len(b) > maxCells {
+		var ops []Op
+		ops = appendOp(ops, OpDelete, a...)
+		return appendOp(ops, OpInsert, b...)
+	}
+
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+
+	var ops []Op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = appendOp(ops, OpEqual, a[i])
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			ops = appendOp(ops, OpDelete, a[i])
+			i++
+		default:
+			ops = appendOp(ops, OpInsert, b[j])
+			j++
+		}
+	}
+	ops = appendOp(ops, OpDelete, a[i:]...)
+	return appendOp(ops, OpInsert, b[j:]...)
+}
+
+func appendOp(ops []Op, typ string, tokens ...string) []Op {
+	if len(tokens) == 0 {
+		return ops
+	}
+	return append(ops, Op{Type: typ, Text: strings.Join(tokens, "")})
+}
+
+func merge(ops []Op) []Op {
+	var out []Op
+	for _, op := range ops {
+		if n := len(out); n > 0 && out[n-1].Type == op.Type {
+			out[n-1].Text += op.Text
+			continue
+		}
+		out = append(out, op)
+	}
+	return out
+}
+
+func tokenize(s string) []string {
+	var tokens []string
+	start := 0
+	space := false
+	for i, r := range s {
+		isSpace := unicode.IsSpace(r)
+		if i > start && isSpace != space {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		space = isSpace
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
@@ -0,0 +1,115 @@
+package hashtag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxLength is the longest normalised tag, in runes, that is still
+	// recognised.
+	MaxLength = 100
+	// MaxPerText caps how many distinct tags a single text can carry.
+	MaxPerText = 30
+)
+
+var folder = cases.Fold()
+
+// Match is a hashtag found by Find. Start and End are byte offsets, End is
+// exclusive and the range includes the sign.
+type Match struct {
+	Name       string
+	Start, End int
+}
+
+// Extract returns the normalised, de-duplicated hashtags found in text, in
+// order of first appearance.
+func Extract(text string) []string {
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, m := range Find(text) {
+		if seen[m.Name] {
+			continue
+		}
+		seen[m.Name] = true
+		tags = append(tags, m.Name)
+		if len(tags) == MaxPerText {
+			break
+		}
+	}
+	return tags
+}
+
+// Find returns every occurrence of a valid hashtag in text with its
+// normalised name.
+func Find(text string) []Match {
+	matches := []Match{}
+
+	prev := ' '
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !isSign(r) || !canPrecede(prev) {
+			prev = r
+			i += size
+			continue
+		}
+
+		start := i + size
+		end := start
+		for end < len(text) {
+			c, n := utf8.DecodeRuneInString(text[end:])
+			if !isTagRune(c) {
+				break
+			}
+			end += n
+		}
+
+		if name := Normalize(text[start:end]); isValid(name) {
+			matches = append(matches, Match{Name: name, Start: i, End: end})
+		}
+
+		if end == start {
+			prev = r
+			i = start
+			continue
+		}
+		prev, _ = utf8.DecodeLastRuneInString(text[:end])
+		i = end
+	}
+
+	return matches
+}
+
+// Normalize folds a tag so that visually equivalent spellings, such as
+// "#Go", "#GO" and the full-width "#Ｇｏ", map to the same name.
+func Normalize(tag string) string {
+	tag = strings.TrimLeftFunc(tag, isSign)
+	return norm.NFKC.String(folder.String(norm.NFKC.String(tag)))
+}
+
+func isSign(r rune) bool {
+	return r == '#' || r == '＃'
+}
+
+// canPrecede rejects signs glued to a word, a URL fragment or an HTML
+// entity, e.g. "C#", "/page#top" and "&#39;".
+func canPrecede(r rune) bool {
+	return !isTagRune(r) && !isSign(r) && r != '&' && r != '/'
+}
+
+func isTagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+func isValid(tag string) bool {
+	n := utf8.RuneCountInString(tag)
+	if n == 0 || n > MaxLength {
+		return false
+	}
+	// Purely numeric tags are usually issue numbers or rankings, "#1".
+	return strings.IndexFunc(tag, func(r rune) bool { return !unicode.IsDigit(r) && r != '_' }) >= 0
+}
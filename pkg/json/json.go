@@ -15,6 +15,18 @@ func Write(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// ErrorResponse is the body of an error that clients tell apart by code
+// rather than by message.
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error writes an error with a machine-readable code.
+func Error(w http.ResponseWriter, status int, code, message string) {
+	Write(w, status, ErrorResponse{Code: code, Message: message})
+}
+
 func Read(r *http.Request, data any) error {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
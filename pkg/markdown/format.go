@@ -0,0 +1,24 @@
+package markdown
+
+import (
+	"context"
+	"net/http"
+)
+
+type formatKey struct{}
+
+// Negotiate marks requests made with ?format=html, so that handlers include
+// the rendered HTML of posts and comments in their responses.
+func Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") == "html" {
+			r = r.WithContext(context.WithValue(r.Context(), formatKey{}, true))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func HTMLRequested(ctx context.Context) bool {
+	html, _ := ctx.Value(formatKey{}).(bool)
+	return html
+}
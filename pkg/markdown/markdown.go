@@ -0,0 +1,182 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/etherealsense/social-network/pkg/hashtag"
+	"github.com/etherealsense/social-network/pkg/mention"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Link targets for autolinked mentions and hashtags. They point at the API
+// resources listing the posts of a user and of a tag.
+const (
+	MentionPath = "/api/v1/posts/user/%d"
+	HashtagPath = "/api/v1/hashtags/%s/posts"
+)
+
+var usersKey = parser.NewContextKey()
+
+var md = goldmark.New(
+	goldmark.WithParserOptions(
+		parser.WithASTTransformers(util.Prioritized(linker{}, 100)),
+	),
+)
+
+// policy is the allowlist applied to rendered HTML. Raw HTML in the source is
+// already dropped by goldmark; this guards against anything that slips
+// through link destinations or future extensions.
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"p", "br", "hr", "strong", "em", "code", "pre", "blockquote",
+		"ul", "ol", "li", "h1", "h2", "h3", "h4", "h5", "h6",
+	)
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^(mention|hashtag)$`)).OnElements("a")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowStandardURLs()
+	p.RequireNoFollowOnLinks(true)
+	return p
+}()
+
+// Render converts CommonMark source to sanitised HTML. Mentions whose handle
+// is in users, which maps handles to user ids, and hashtags become links.
+func Render(src string, users map[string]int32) string {
+	pc := parser.NewContext()
+	pc.Set(usersKey, users)
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf, parser.WithContext(pc)); err != nil {
+		// Rendering into a buffer does not fail; keep the text readable
+		// if it ever does.
+		return policy.Sanitize("<p>" + src + "</p>")
+	}
+	return policy.Sanitize(buf.String())
+}
+
+type entity struct {
+	start, end int
+	class      string
+	href       string
+}
+
+// linker turns mentions and hashtags in plain text nodes into links. Text in
+// links, images and code is left alone.
+type linker struct{}
+
+func (linker) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	users, _ := pc.Get(usersKey).(map[string]int32)
+	source := reader.Source()
+
+	var runs [][]*ast.Text
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindLink, ast.KindAutoLink, ast.KindImage, ast.KindCodeSpan,
+			ast.KindCodeBlock, ast.KindFencedCodeBlock, ast.KindHTMLBlock, ast.KindRawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		runs = append(runs, textRuns(n)...)
+		return ast.WalkContinue, nil
+	})
+
+	for _, run := range runs {
+		link(run, source, users)
+	}
+}
+
+// textRuns groups the direct text children of n that are contiguous in the
+// source. Inline parsing splits text at characters such as "_", which may be
+// part of a handle or a tag.
+func textRuns(n ast.Node) [][]*ast.Text {
+	var runs [][]*ast.Text
+	var run []*ast.Text
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		t, ok := c.(*ast.Text)
+		if !ok || t.IsRaw() {
+			if len(run) > 0 {
+				runs = append(runs, run)
+			}
+			run = nil
+			continue
+		}
+
+		if len(run) > 0 {
+			last := run[len(run)-1]
+			if last.SoftLineBreak() || last.HardLineBreak() || last.Segment.Stop != t.Segment.Start {
+				runs = append(runs, run)
+				run = nil
+			}
+		}
+		run = append(run, t)
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+func link(run []*ast.Text, source []byte, users map[string]int32) {
+	first, last := run[0], run[len(run)-1]
+	start, stop := first.Segment.Start, last.Segment.Stop
+	value := string(source[start:stop])
+
+	var entities []entity
+	for _, m := range mention.Find(value) {
+		if id, ok := users[m.Handle]; ok {
+			entities = append(entities, entity{m.Start, m.End, "mention", fmt.Sprintf(MentionPath, id)})
+		}
+	}
+	for _, m := range hashtag.Find(value) {
+		entities = append(entities, entity{m.Start, m.End, "hashtag", fmt.Sprintf(HashtagPath, url.PathEscape(m.Name))})
+	}
+	if len(entities) == 0 {
+		return
+	}
+	slices.SortFunc(entities, func(a, b entity) int { return a.start - b.start })
+
+	parent := first.Parent()
+	pos := 0
+	for _, e := range entities {
+		// A backslash-escaped sign, "\@bob", is meant literally.
+		if e.start < pos || e.start > 0 && value[e.start-1] == '\\' {
+			continue
+		}
+		if e.start > pos {
+			parent.InsertBefore(parent, first, segment(start+pos, start+e.start))
+		}
+
+		l := ast.NewLink()
+		l.Destination = []byte(e.href)
+		l.SetAttributeString("class", []byte(e.class))
+		l.AppendChild(l, segment(start+e.start, start+e.end))
+		parent.InsertBefore(parent, first, l)
+		pos = e.end
+	}
+
+	tail := segment(start+pos, stop)
+	tail.SetSoftLineBreak(last.SoftLineBreak())
+	tail.SetHardLineBreak(last.HardLineBreak())
+	parent.InsertBefore(parent, first, tail)
+
+	for _, t := range run {
+		parent.RemoveChild(parent, t)
+	}
+}
+
+func segment(start, stop int) *ast.Text {
+	return ast.NewTextSegment(text.NewSegment(start, stop))
+}
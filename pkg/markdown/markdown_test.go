@@ -0,0 +1,109 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+var testUsers = map[string]int32{"bob": 7, "alice_b": 9}
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		absent  []string
+		present []string
+	}{
+		{
+			name:   "javascript link",
+			src:    "[click](javascript:alert(1))",
+			absent: []string{"javascript:", "<a"},
+		},
+		{
+			name:   "javascript link with entities",
+			src:    "[click](jav&#x09;ascript:alert(1))",
+			absent: []string{"javascript", "ascript:", "<a"},
+		},
+		{
+			name:   "javascript autolink",
+			src:    "<javascript:alert(1)>",
+			absent: []string{"href=\"javascript"},
+		},
+		{
+			name:   "data link",
+			src:    "[x](data:text/html;base64,PHNjcmlwdD4=)",
+			absent: []string{"data:", "<a"},
+		},
+		{
+			name:   "raw html block",
+			src:    "<script>alert(1)</script>",
+			absent: []string{"<script", "alert(1)</script>"},
+		},
+		{
+			name:   "inline raw html",
+			src:    "hi <img src=x onerror=alert(1)> there",
+			absent: []string{"<img", "onerror"},
+		},
+		{
+			name:   "event handler attribute",
+			src:    `<a href="https://example.com" onclick="alert(1)">x</a>`,
+			absent: []string{"onclick"},
+		},
+		{
+			name:    "safe link",
+			src:     "[site](https://example.com)",
+			present: []string{`<a href="https://example.com" rel="nofollow">site</a>`},
+		},
+		{
+			name:    "code class",
+			src:     "```go\nfmt.Println()\n```",
+			present: []string{`<code class="language-go">`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(tt.src, testUsers)
+			for _, s := range tt.absent {
+				if strings.Contains(got, s) {
+					t.Errorf("Render(%q) = %q, contains %q", tt.src, got, s)
+				}
+			}
+			for _, s := range tt.present {
+				if !strings.Contains(got, s) {
+					t.Errorf("Render(%q) = %q, missing %q", tt.src, got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderLinksEntities(t *testing.T) {
+	mentionBob := `<a href="/api/v1/posts/user/7" class="mention" rel="nofollow">@bob</a>`
+	tagGo := `<a href="/api/v1/hashtags/golang/posts" class="hashtag" rel="nofollow">#golang</a>`
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"mention", "hi @bob!", "<p>hi " + mentionBob + "!</p>\n"},
+		{"hashtag", "learning #golang", "<p>learning " + tagGo + "</p>\n"},
+		{"handle with underscore", "cc @alice_b", `<p>cc <a href="/api/v1/posts/user/9" class="mention" rel="nofollow">@alice_b</a></p>` + "\n"},
+		{"unknown handle", "hi @carol", "<p>hi @carol</p>\n"},
+		{"inside emphasis", "*@bob*", "<p><em>" + mentionBob + "</em></p>\n"},
+		{"code span", "`@bob #golang`", "<p><code>@bob #golang</code></p>\n"},
+		{"code block", "    @bob #golang\n", "<pre><code>@bob #golang\n</code></pre>\n"},
+		{"fenced code", "```\n@bob #golang\n```", "<pre><code>@bob #golang\n</code></pre>\n"},
+		{"link text", "[@bob](https://example.com)", `<p><a href="https://example.com" rel="nofollow">@bob</a></p>` + "\n"},
+		{"escaped mention", `\@bob`, "<p>@bob</p>\n"},
+		{"escaped hashtag", `\#golang`, "<p>#golang</p>\n"},
+		{"email address", "mail bob@example.com", "<p>mail bob@example.com</p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.src, testUsers); got != tt.want {
+				t.Errorf("Render(%q)\n got %q\nwant %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
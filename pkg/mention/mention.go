@@ -0,0 +1,127 @@
+package mention
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxHandleLength mirrors the users.handle column.
+const MaxHandleLength = 30
+
+const TypeMention = "mention"
+
+// Entity marks a resolved mention inside a text. Start and End are offsets
+// in Unicode code points, End is exclusive and the range includes the "@".
+type Entity struct {
+	Type   string `json:"type"`
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+	UserID int32  `json:"user_id"`
+	Handle string `json:"handle"`
+}
+
+// Match is a mention found by Find. Start and End are byte offsets, End is
+// exclusive and the range includes the "@".
+type Match struct {
+	Handle     string
+	Start, End int
+}
+
+type match struct {
+	handle     string
+	start, end int
+	byteStart  int
+	byteEnd    int
+}
+
+// Handles returns the distinct, lower-cased handles mentioned in text.
+func Handles(text string) []string {
+	handles := []string{}
+	seen := make(map[string]bool)
+	for _, m := range parse(text) {
+		if !seen[m.handle] {
+			seen[m.handle] = true
+			handles = append(handles, m.handle)
+		}
+	}
+	return handles
+}
+
+// Entities returns a range for every mention in text whose handle is in
+// users, which maps handles to the ids they resolved to. Handles that did
+// not resolve are left as plain text.
+func Entities(text string, users map[string]int32) []Entity {
+	entities := []Entity{}
+	for _, m := range parse(text) {
+		id, ok := users[m.handle]
+		if !ok {
+			continue
+		}
+		entities = append(entities, Entity{
+			Type:   TypeMention,
+			Start:  m.start,
+			End:    m.end,
+			UserID: id,
+			Handle: m.handle,
+		})
+	}
+	return entities
+}
+
+// Find returns every mention-shaped handle in text, resolved or not.
+func Find(text string) []Match {
+	matches := []Match{}
+	for _, m := range parse(text) {
+		matches = append(matches, Match{Handle: m.handle, Start: m.byteStart, End: m.byteEnd})
+	}
+	return matches
+}
+
+func parse(text string) []match {
+	var matches []match
+
+	prev := ' '
+	pos := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r != '@' || !canPrecede(prev) {
+			prev = r
+			pos++
+			i += size
+			continue
+		}
+
+		// Handles are ASCII, so byte and code point lengths agree here.
+		end := i + 1
+		for end < len(text) && isHandleByte(rune(text[end])) {
+			end++
+		}
+
+		n := end - i - 1
+		if n > 0 && n <= MaxHandleLength {
+			matches = append(matches, match{
+				handle:    strings.ToLower(text[i+1 : end]),
+				start:     pos,
+				end:       pos + 1 + n,
+				byteStart: i,
+				byteEnd:   end,
+			})
+		}
+
+		pos += 1 + n
+		prev = rune(text[end-1])
+		i = end
+	}
+
+	return matches
+}
+
+// canPrecede rejects an "@" glued to a handle-like word, as in an email
+// address. Other scripts may run straight into a mention, "日本@bob".
+func canPrecede(r rune) bool {
+	return !isHandleByte(r) && !strings.ContainsRune("!#$%&*@＠", r)
+}
+
+func isHandleByte(r rune) bool {
+	return r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
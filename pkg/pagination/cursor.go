@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// MinSecretLen is the shortest key SetSecret accepts, the size of the
+// HMAC-SHA256 output.
+const MinSecretLen = 32
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrShortSecret   = errors.New("cursor secret must be at least 32 bytes")
+)
+
+// secret signs cursors so clients cannot forge sort keys. It is random until
+// SetSecret is called, which invalidates cursors on restart.
+var secret = func() []byte {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return b
+}()
+
+// SetSecret sets the key cursors are signed with. It must be called before
+// the server starts handling requests.
+func SetSecret(key []byte) error {
+	if len(key) < MinSecretLen {
+		return ErrShortSecret
+	}
+	secret = key
+	return nil
+}
+
+// Key is the sort key of an item in a list. Time is the sort time, or for
+// lists ranked by a time-decayed score, the time the scores were computed
+// at. Score is set for ranked lists. Type is set for lists that merge items
+// of several types, whose ids may collide, and breaks ties before ID.
+type Key struct {
+	Time  time.Time `json:"t,omitzero"`
+	Score float64   `json:"s,omitempty"`
+	Type  string    `json:"k,omitempty"`
+	ID    int32     `json:"i"`
+}
+
+// Cursor points at an item of a list. A forward cursor selects the items
+// after it, a backward cursor the items before it.
+type Cursor struct {
+	Key
+	Backward bool `json:"b,omitempty"`
+}
+
+// EncodeCursor turns a cursor into an opaque, signed token.
+func EncodeCursor(c Cursor) string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload))
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+	encoded, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	sum, err := base64.RawURLEncoding.DecodeString(mac)
+	if err != nil || !hmac.Equal(sum, sign(payload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,80 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	valid := EncodeCursor(Cursor{
+		Key:      Key{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Score: 1.5, ID: 42},
+		Backward: true,
+	})
+	payload, mac, _ := strings.Cut(valid, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"i":1}`))
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"valid", valid, nil},
+		{"empty", "", ErrInvalidCursor},
+		{"no signature", payload, ErrInvalidCursor},
+		{"empty signature", payload + ".", ErrInvalidCursor},
+		{"forged payload", forged + "." + mac, ErrInvalidCursor},
+		{"truncated signature", payload + "." + mac[:len(mac)-2], ErrInvalidCursor},
+		{"payload not base64", "!!!." + mac, ErrInvalidCursor},
+		{"signature not base64", payload + ".!!!", ErrInvalidCursor},
+		{"extra part", valid + ".x", ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := DecodeCursor(tt.token)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("DecodeCursor() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (c.ID != 42 || c.Score != 1.5 || !c.Backward || !c.Time.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))) {
+				t.Errorf("DecodeCursor() = %+v, want the encoded cursor", c)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorOtherSecret(t *testing.T) {
+	old := secret
+	t.Cleanup(func() { secret = old })
+
+	token := EncodeCursor(Cursor{Key: Key{ID: 1}})
+	if err := SetSecret([]byte(strings.Repeat("k", MinSecretLen))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeCursor(token); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("DecodeCursor() error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestSetSecret(t *testing.T) {
+	old := secret
+	t.Cleanup(func() { secret = old })
+
+	tests := []struct {
+		name string
+		key  string
+		err  error
+	}{
+		{"empty", "", ErrShortSecret},
+		{"short", strings.Repeat("k", MinSecretLen-1), ErrShortSecret},
+		{"minimum", strings.Repeat("k", MinSecretLen), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetSecret([]byte(tt.key)); !errors.Is(err, tt.err) {
+				t.Errorf("SetSecret() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
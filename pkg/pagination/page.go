@@ -0,0 +1,56 @@
+package pagination
+
+import "slices"
+
+// Page is the envelope of every list response. NextCursor and PrevCursor are
+// empty when there is no page in that direction.
+type Page[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+type Cursors struct {
+	Next string
+	Prev string
+}
+
+// NewPage wraps data in the response envelope.
+func NewPage[T any](data []T, c Cursors) Page[T] {
+	if data == nil {
+		data = []T{}
+	}
+	return Page[T]{Data: data, NextCursor: c.Next, PrevCursor: c.Prev}
+}
+
+// Paginate trims rows, queried with p.Fetch() as the limit, to one page in
+// list order and returns the cursors around it. key returns the sort key of a
+// row.
+func Paginate[T any](rows []T, p Params, key func(T) Key) ([]T, Cursors) {
+	more := len(rows) > int(p.Limit)
+	if more {
+		rows = rows[:p.Limit]
+	}
+
+	var c Cursors
+	if len(rows) == 0 {
+		return rows, c
+	}
+
+	if p.Backward() {
+		slices.Reverse(rows)
+		if more {
+			c.Prev = EncodeCursor(Cursor{Key: key(rows[0]), Backward: true})
+		}
+		c.Next = EncodeCursor(Cursor{Key: key(rows[len(rows)-1])})
+		return rows, c
+	}
+
+	if more {
+		c.Next = EncodeCursor(Cursor{Key: key(rows[len(rows)-1])})
+	}
+	if p.Cursor != nil || p.Offset > 0 {
+		c.Prev = EncodeCursor(Cursor{Key: key(rows[0]), Backward: true})
+	}
+	return rows, c
+}
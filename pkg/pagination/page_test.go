@@ -0,0 +1,60 @@
+package pagination
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	key := func(id int32) Key { return Key{ID: id} }
+	forward := &Cursor{Key: Key{ID: 10}}
+	backward := &Cursor{Key: Key{ID: 10}, Backward: true}
+
+	// want* are the ids the cursors point at, with 0 meaning no cursor.
+	tests := []struct {
+		name     string
+		rows     []int32
+		params   Params
+		want     []int32
+		wantNext int32
+		wantPrev int32
+	}{
+		{"empty", nil, Params{Limit: 2}, nil, 0, 0},
+		{"single page", []int32{1, 2}, Params{Limit: 2}, []int32{1, 2}, 0, 0},
+		{"first page", []int32{1, 2, 3}, Params{Limit: 2}, []int32{1, 2}, 2, 0},
+		{"offset", []int32{3, 4}, Params{Limit: 2, Offset: 2}, []int32{3, 4}, 0, 3},
+		{"offset with more", []int32{3, 4, 5}, Params{Limit: 2, Offset: 2}, []int32{3, 4}, 4, 3},
+		{"after cursor", []int32{11, 12, 13}, Params{Limit: 2, Cursor: forward}, []int32{11, 12}, 12, 11},
+		{"last page after cursor", []int32{11}, Params{Limit: 2, Cursor: forward}, []int32{11}, 0, 11},
+		{"before cursor", []int32{9, 8, 7}, Params{Limit: 2, Cursor: backward}, []int32{8, 9}, 9, 8},
+		{"first page before cursor", []int32{9}, Params{Limit: 2, Cursor: backward}, []int32{9}, 9, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, c := Paginate(slices.Clone(tt.rows), tt.params, key)
+			if !slices.Equal(rows, tt.want) {
+				t.Errorf("rows = %v, want %v", rows, tt.want)
+			}
+			checkCursor(t, "next", c.Next, tt.wantNext, false)
+			checkCursor(t, "prev", c.Prev, tt.wantPrev, true)
+		})
+	}
+}
+
+func checkCursor(t *testing.T, name, token string, wantID int32, wantBackward bool) {
+	t.Helper()
+	if wantID == 0 {
+		if token != "" {
+			t.Errorf("%s cursor = %q, want none", name, token)
+		}
+		return
+	}
+
+	c, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("%s cursor: %v", name, err)
+	}
+	if c.ID != wantID || c.Backward != wantBackward {
+		t.Errorf("%s cursor = %+v, want id %d backward %v", name, c, wantID, wantBackward)
+	}
+}
@@ -3,14 +3,22 @@ package pagination
 import (
 	"net/http"
 	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Params selects one page of a list. Lists are paged by Cursor when the
+// request carries one and by Offset otherwise.
 type Params struct {
 	Limit  int32
 	Offset int32
+	Cursor *Cursor
 }
 
-func Parse(r *http.Request) Params {
+// Parse reads the limit, cursor and offset query parameters. The offset is
+// ignored when a cursor is given.
+func Parse(r *http.Request) (Params, error) {
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 
@@ -21,5 +29,69 @@ func Parse(r *http.Request) Params {
 		offset = 0
 	}
 
-	return Params{Limit: int32(limit), Offset: int32(offset)}
+	p := Params{Limit: int32(limit), Offset: int32(offset)}
+
+	if token := r.URL.Query().Get("cursor"); token != "" {
+		c, err := DecodeCursor(token)
+		if err != nil {
+			return Params{}, err
+		}
+		p.Cursor = &c
+		p.Offset = 0
+	}
+	return p, nil
+}
+
+// Fetch is the number of rows to query. The extra row tells Paginate whether
+// another page follows.
+func (p Params) Fetch() int32 {
+	return p.Limit + 1
+}
+
+// Backward reports whether the page lies before the cursor. Queries then
+// return rows in reverse order, starting next to the cursor.
+func (p Params) Backward() bool {
+	return p.Cursor != nil && p.Cursor.Backward
+}
+
+// CursorTime is the time of the cursor key, or null without a cursor.
+func (p Params) CursorTime() pgtype.Timestamptz {
+	if p.Cursor == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: p.Cursor.Time, Valid: true}
+}
+
+// CursorScore is the score of the cursor key, or null without a cursor.
+func (p Params) CursorScore() pgtype.Float8 {
+	if p.Cursor == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: p.Cursor.Score, Valid: true}
+}
+
+// CursorType is the item type of the cursor key, or empty without a cursor.
+func (p Params) CursorType() string {
+	if p.Cursor == nil {
+		return ""
+	}
+	return p.Cursor.Type
+}
+
+// CursorID is the id of the cursor key, or 0 without a cursor.
+func (p Params) CursorID() int32 {
+	if p.Cursor == nil {
+		return 0
+	}
+	return p.Cursor.ID
+}
+
+// AsOf is the time scores are computed at in lists ranked by a time-decayed
+// score. Every page of such a list reuses the time of its first page, so
+// items do not shift between pages as they age.
+func (p Params) AsOf() time.Time {
+	if p.Cursor == nil {
+		return time.Now()
+	}
+	return p.Cursor.Time
 }
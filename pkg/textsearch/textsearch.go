@@ -0,0 +1,121 @@
+// Package textsearch turns search input into PostgreSQL tsquery syntax and
+// lists the text search configurations rows can be indexed with.
+package textsearch
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const DefaultLanguage = "english"
+
+var ErrEmptyQuery = errors.New("query must contain at least one word")
+
+// languages are the text search configurations shipped with PostgreSQL.
+var languages = map[string]bool{
+	"simple": true, "arabic": true, "armenian": true, "basque": true, "catalan": true,
+	"danish": true, "dutch": true, "english": true, "finnish": true, "french": true,
+	"german": true, "greek": true, "hindi": true, "hungarian": true, "indonesian": true,
+	"irish": true, "italian": true, "lithuanian": true, "nepali": true, "norwegian": true,
+	"portuguese": true, "romanian": true, "russian": true, "serbian": true, "spanish": true,
+	"swedish": true, "tamil": true, "turkish": true, "yiddish": true,
+}
+
+// ValidLanguage reports whether language names a text search configuration.
+// Only valid names may be cast to regconfig.
+func ValidLanguage(language string) bool {
+	return languages[language]
+}
+
+type term struct {
+	words  []string
+	prefix bool
+	negate bool
+	or     bool
+}
+
+// ParseQuery converts search input to the syntax of to_tsquery. Terms are
+// combined with AND. Quoted text matches as a phrase, a trailing * matches
+// words by prefix, a leading - excludes a term and OR between two terms
+// matches either. Punctuation is dropped, so the result is always valid
+// tsquery syntax.
+func ParseQuery(input string) (string, error) {
+	var terms []term
+	or := false
+
+	for rest := strings.TrimSpace(input); rest != ""; rest = strings.TrimSpace(rest) {
+		var t term
+		if rest[0] == '-' {
+			t.negate = true
+			rest = rest[1:]
+		}
+
+		var raw string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				raw, rest = rest[1:], ""
+			} else {
+				raw, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			raw, rest = rest[:end], rest[end:]
+
+			if raw == "OR" && !t.negate {
+				or = len(terms) > 0
+				continue
+			}
+			t.prefix = strings.HasSuffix(raw, "*")
+		}
+
+		t.words = words(raw)
+		if len(t.words) == 0 {
+			continue
+		}
+		t.or = or
+		or = false
+		terms = append(terms, t)
+	}
+
+	var b strings.Builder
+	positive := false
+	for i, t := range terms {
+		if i > 0 {
+			if t.or {
+				b.WriteString(" | ")
+			} else {
+				b.WriteString(" & ")
+			}
+		}
+		if t.negate {
+			b.WriteString("!")
+		} else {
+			positive = true
+		}
+
+		b.WriteString("(")
+		b.WriteString(strings.Join(t.words, " <-> "))
+		if t.prefix {
+			// Only the last word of "e-mai*" is a prefix.
+			b.WriteString(":*")
+		}
+		b.WriteString(")")
+	}
+
+	if !positive {
+		return "", ErrEmptyQuery
+	}
+	return b.String(), nil
+}
+
+// words splits s into runs of letters and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,45 @@
+package textsearch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		err   error
+	}{
+		{"single word", "go", "(go)", nil},
+		{"words are anded", "go  postgres", "(go) & (postgres)", nil},
+		{"phrase", `"fast food"`, "(fast <-> food)", nil},
+		{"unterminated phrase", `"fast food`, "(fast <-> food)", nil},
+		{"prefix", "post*", "(post:*)", nil},
+		{"prefix of split word", "e-mai*", "(e <-> mai:*)", nil},
+		{"negated", "go -java", "(go) & !(java)", nil},
+		{"negated phrase", `go -"java script"`, "(go) & !(java <-> script)", nil},
+		{"or", "go OR rust", "(go) | (rust)", nil},
+		{"leading or", "OR go", "(go)", nil},
+		{"trailing or", "go OR", "(go)", nil},
+		{"lowercase or is a word", "go or rust", "(go) & (or) & (rust)", nil},
+		{"negated or is a word", "go -OR", "(go) & !(OR)", nil},
+		{"punctuation dropped", "c++ & (x|y)!", "(c) & (x <-> y)", nil},
+		{"unicode", "café naïve", "(café) & (naïve)", nil},
+		{"empty", "", "", ErrEmptyQuery},
+		{"only punctuation", "!& |", "", ErrEmptyQuery},
+		{"only negated", "-go -rust", "", ErrEmptyQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseQuery(%q) error = %v, want %v", tt.input, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseQuery(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package validator
+
+import (
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrHandleInvalid = errors.New("handle must be 3 to 30 lowercase letters, digits or underscores")
+	ErrHandleEmpty   = errors.New("handle cannot be empty")
+)
+
+var handleRegex = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+
+func ValidateHandle(handle string) error {
+	if handle == "" {
+		return ErrHandleEmpty
+	}
+
+	if !handleRegex.MatchString(handle) {
+		return ErrHandleInvalid
+	}
+
+	return nil
+}